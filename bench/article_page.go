@@ -0,0 +1,197 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package bench
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// articlePageSizeHint is the number of bytes of text that articlePage always writes.
+// It can be used to size buffers for the output.
+const articlePageSizeHint = 79
+
+func articlePage(buf *bytes.Buffer, data any) {
+	buf.Grow(articlePageSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	_articlePage_p0(buf, "", stack, stack.PushBlocks(m.Blocks{}, _articlePage_t0))
+	stack.PopBlocks()
+}
+
+func _articlePage_p0(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	_articlePage_p1(buf, indent, stack, stack.PushBlocks(blocks, _articlePage_t1))
+	stack.PopBlocks()
+}
+
+func _articlePage_p1(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	_articlePage_p2(buf, indent, stack, stack.PushBlocks(blocks, _articlePage_t2))
+	stack.PopBlocks()
+}
+
+func _articlePage_p2(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	buf.WriteString("<!DOCTYPE html>\n")
+	buf.WriteString(indent)
+	buf.WriteString("<html>\n")
+	buf.WriteString(indent)
+	buf.WriteString("<head>\n")
+	buf.WriteString(indent)
+	buf.WriteString("<title>")
+	if b, env := stack.Block(blocks, "title"); b != nil {
+		b(buf, "", stack, env)
+	} else {
+		buf.WriteString("Site")
+	}
+	buf.WriteString("</title>\n")
+	if b, env := stack.Block(blocks, "head"); b != nil {
+		b(buf, "", stack, env)
+	} else {
+	}
+	buf.WriteString("\n")
+	buf.WriteString(indent)
+	buf.WriteString("</head>\n")
+	buf.WriteString(indent)
+	buf.WriteString("<body>\n")
+	if b, env := stack.Block(blocks, "body"); b != nil {
+		b(buf, "", stack, env)
+	} else {
+	}
+	buf.WriteString("\n")
+	if b, env := stack.Block(blocks, "footer"); b != nil {
+		b(buf, indent, stack, env)
+	} else {
+		buf.WriteString(indent)
+		buf.WriteString("<footer>Copyright ")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Year")))
+		buf.WriteString("</footer>\n")
+	}
+	buf.WriteString(indent)
+	buf.WriteString("</body>\n")
+	buf.WriteString(indent)
+	buf.WriteString("</html>\n")
+}
+
+func _articlePage_b0(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Title")))
+}
+
+func _articlePage_b1(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	for it := stack.Iterate(stack.Lookup("Related")); it.Next(); {
+		stack.Push(it.Value())
+		buf.WriteString(indent)
+		buf.WriteString("<a href=\"")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("URL")))
+		buf.WriteString("\">")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Title")))
+		buf.WriteString("</a>\n")
+		stack.Pop()
+	}
+}
+
+func _articlePage_b2(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	buf.WriteString("<h1>")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Title")))
+	buf.WriteString("</h1>\n")
+	for it := stack.Iterate(stack.Lookup("Paragraphs")); it.Next(); {
+		stack.Push(it.Value())
+		buf.WriteString(indent)
+		buf.WriteString("<p>")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Top()))
+		buf.WriteString("</p>\n")
+		stack.Pop()
+	}
+}
+
+func _articlePage_t0(name string) m.BlockFunc {
+	switch name {
+	case "pageTitle":
+		return _articlePage_b0
+	case "sidebar":
+		return _articlePage_b1
+	case "article":
+		return _articlePage_b2
+	}
+	return nil
+}
+
+func _articlePage_b3(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	buf.WriteString("<aside>\n")
+	if b, env := stack.Block(blocks, "sidebar"); b != nil {
+		b(buf, "", stack, env)
+	} else {
+	}
+	buf.WriteString("\n")
+	buf.WriteString(indent)
+	buf.WriteString("</aside>\n")
+	buf.WriteString(indent)
+	buf.WriteString("<article>\n")
+	if b, env := stack.Block(blocks, "article"); b != nil {
+		b(buf, "", stack, env)
+	} else {
+	}
+	buf.WriteString("\n")
+	buf.WriteString(indent)
+	buf.WriteString("</article>\n")
+}
+
+func _articlePage_t1(name string) m.BlockFunc {
+	switch name {
+	case "content":
+		return _articlePage_b3
+	}
+	return nil
+}
+
+func _articlePage_b4(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	if b, env := stack.Block(blocks, "pageTitle"); b != nil {
+		b(buf, "", stack, env)
+	} else {
+		buf.WriteString("Page")
+	}
+	buf.WriteString(" - Site")
+}
+
+func _articlePage_b5(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	buf.WriteString("<nav>\n")
+	for it := stack.Iterate(stack.Lookup("Nav")); it.Next(); {
+		stack.Push(it.Value())
+		buf.WriteString(indent)
+		buf.WriteString("<a href=\"")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("URL")))
+		buf.WriteString("\">")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Title")))
+		buf.WriteString("</a>\n")
+		stack.Pop()
+	}
+	buf.WriteString(indent)
+	buf.WriteString("</nav>\n")
+	buf.WriteString(indent)
+	buf.WriteString("<main>\n")
+	if b, env := stack.Block(blocks, "content"); b != nil {
+		b(buf, "", stack, env)
+	} else {
+	}
+	buf.WriteString("\n")
+	buf.WriteString(indent)
+	buf.WriteString("</main>\n")
+}
+
+func _articlePage_t2(name string) m.BlockFunc {
+	switch name {
+	case "title":
+		return _articlePage_b4
+	case "body":
+		return _articlePage_b5
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+// Package bench compares the performance of Go functions generated by mustache-codegen
+// against equivalent [text/template] and [html/template] templates.
+// The templates are in the testdata directory.
+//
+// Run the benchmarks with:
+//
+//	go test -bench=. ./bench
+package bench
+
+//go:generate go run ../cmd/mustache-codegen -lang=go -go-package=bench -go-unexported -o list_page.go testdata/list_page.mustache
+//go:generate go run ../cmd/mustache-codegen -lang=go -go-package=bench -go-unexported -o article_page.go testdata/article_page.mustache
+//go:generate go run ../cmd/mustache-codegen -lang=go -go-package=bench -go-unexported -o thread.go testdata/thread.mustache
+
+// ListPage is the data for the list_page templates.
+type ListPage struct {
+	Query   string
+	Results []Result
+}
+
+// Result is an item in a [ListPage].
+type Result struct {
+	URL     string
+	Title   string
+	Snippet string
+	Tags    []string
+}
+
+// ArticlePage is the data for the article_page templates.
+type ArticlePage struct {
+	Year       int
+	Nav        []Link
+	Title      string
+	Related    []Link
+	Paragraphs []string
+}
+
+// Link is a hyperlink.
+type Link struct {
+	URL   string
+	Title string
+}
+
+// Thread is the data for the thread templates.
+type Thread struct {
+	Title    string
+	Comments []*Comment
+}
+
+// Comment is a node in a [Thread].
+type Comment struct {
+	Author  string
+	Date    string
+	Body    string
+	Replies []*Comment
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package bench
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+	"text/template/parse"
+
+	"github.com/kagisearch/mustache-codegen/go/mustache/interp"
+)
+
+//go:embed testdata/*.tmpl
+var goTemplates embed.FS
+
+var (
+	textTemplates = texttemplate.Must(texttemplate.New("").Funcs(texttemplate.FuncMap{
+		// Escape values like mustache-codegen does.
+		// (HTMLEscaper uses different entities for quotes,
+		// but the benchmark data does not contain any.)
+		"escape": texttemplate.HTMLEscaper,
+	}).ParseFS(goTemplates, "testdata/*.tmpl"))
+	htmlTemplates = htmltemplate.Must(htmltemplate.ParseFS(goTemplates, "testdata/*.tmpl"))
+)
+
+// benchmarks is the list of templates to compare.
+// Each template has a mustache-codegen function
+// and a Go template of the same name.
+var benchmarks = []struct {
+	name   string
+	render func(buf *bytes.Buffer, data any)
+	data   any
+}{
+	{"list_page", listPage, newListPage(50)},
+	{"article_page", articlePage, newArticlePage(20)},
+	{"thread", thread, newThread(4, 3)},
+}
+
+func init() {
+	// text/template does not escape HTML automatically,
+	// so pipe every action through the escape function.
+	for _, t := range textTemplates.Templates() {
+		escapeTextTemplate(t)
+	}
+}
+
+// escapeTextTemplate adds the escape function to the end of
+// every action's pipeline in t.
+func escapeTextTemplate(t *texttemplate.Template) {
+	if t.Tree == nil {
+		return
+	}
+	var walk func(n parse.Node)
+	walk = func(n parse.Node) {
+		switch n := n.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			if len(n.Pipe.Decl) == 0 {
+				n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+					NodeType: parse.NodeCommand,
+					Args:     []parse.Node{parse.NewIdentifier("escape")},
+				})
+			}
+		case *parse.IfNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.List)
+			walk(n.ElseList)
+		}
+	}
+	walk(t.Tree.Root)
+}
+
+func TestEquivalent(t *testing.T) {
+	for _, bm := range benchmarks {
+		t.Run(bm.name, func(t *testing.T) {
+			want := new(bytes.Buffer)
+			bm.render(want, bm.data)
+			if !strings.Contains(want.String(), "&lt;") {
+				t.Error("mustache-codegen output does not contain escaped text")
+			}
+
+			got := new(bytes.Buffer)
+			tmpl, err := interp.ParseFile("testdata/" + bm.name + ".mustache")
+			if err != nil {
+				t.Fatal(err)
+			}
+			tmpl.Render(got, bm.data)
+			if diff := lineDiff(got.String(), want.String()); diff != "" {
+				t.Errorf("interpreter output differs from mustache-codegen: %s", diff)
+			}
+
+			got.Reset()
+			if err := textTemplates.ExecuteTemplate(got, bm.name, bm.data); err != nil {
+				t.Fatal(err)
+			}
+			if diff := lineDiff(got.String(), want.String()); diff != "" {
+				t.Errorf("text/template output differs from mustache-codegen: %s", diff)
+			}
+
+			got.Reset()
+			if err := htmlTemplates.ExecuteTemplate(got, bm.name, bm.data); err != nil {
+				t.Fatal(err)
+			}
+			if diff := lineDiff(got.String(), want.String()); diff != "" {
+				t.Errorf("html/template output differs from mustache-codegen: %s", diff)
+			}
+		})
+	}
+}
+
+// lineDiff returns a description of the first line that differs
+// between got and want or the empty string if they are equal.
+func lineDiff(got, want string) string {
+	gotLines := strings.SplitAfter(got, "\n")
+	wantLines := strings.SplitAfter(want, "\n")
+	for i := range max(len(gotLines), len(wantLines)) {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			return fmt.Sprintf("line %d = %q; want %q", i+1, g, w)
+		}
+	}
+	return ""
+}
+
+func BenchmarkRender(b *testing.B) {
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.Run("mustache-codegen", func(b *testing.B) {
+				buf := new(bytes.Buffer)
+				b.ReportAllocs()
+				for range b.N {
+					buf.Reset()
+					bm.render(buf, bm.data)
+				}
+			})
+			b.Run("interpreted", func(b *testing.B) {
+				tmpl, err := interp.ParseFile("testdata/" + bm.name + ".mustache")
+				if err != nil {
+					b.Fatal(err)
+				}
+				buf := new(bytes.Buffer)
+				b.ReportAllocs()
+				for range b.N {
+					buf.Reset()
+					tmpl.Render(buf, bm.data)
+				}
+			})
+			b.Run("text/template", func(b *testing.B) {
+				buf := new(bytes.Buffer)
+				b.ReportAllocs()
+				for range b.N {
+					buf.Reset()
+					if err := textTemplates.ExecuteTemplate(buf, bm.name, bm.data); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+			b.Run("html/template", func(b *testing.B) {
+				buf := new(bytes.Buffer)
+				b.ReportAllocs()
+				for range b.N {
+					buf.Reset()
+					if err := htmlTemplates.ExecuteTemplate(buf, bm.name, bm.data); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		})
+	}
+}
+
+func newListPage(n int) *ListPage {
+	page := &ListPage{Query: "<mustache> & templates"}
+	for i := range n {
+		page.Results = append(page.Results, Result{
+			URL:     fmt.Sprintf("https://example.com/%d?a=1&b=2", i),
+			Title:   fmt.Sprintf("Result #%d: <b>templates</b>", i),
+			Snippet: strings.Repeat("Logic-less templates are <i>simple</i> & fast. ", 4),
+			Tags:    []string{"go", "html", "mustache"},
+		})
+	}
+	return page
+}
+
+func newArticlePage(n int) *ArticlePage {
+	page := &ArticlePage{
+		Year:  2025,
+		Title: "On <templates> & code generation",
+	}
+	for i := range 5 {
+		page.Nav = append(page.Nav, Link{
+			URL:   fmt.Sprintf("/section/%d", i),
+			Title: fmt.Sprintf("Section %d", i),
+		})
+		page.Related = append(page.Related, Link{
+			URL:   fmt.Sprintf("/article/%d?ref=related&pos=%d", i, i),
+			Title: fmt.Sprintf("Related <article> %d", i),
+		})
+	}
+	for i := range n {
+		page.Paragraphs = append(page.Paragraphs,
+			fmt.Sprintf("Paragraph %d. ", i)+strings.Repeat("Some <em>text</em> & more text. ", 8))
+	}
+	return page
+}
+
+// newThread returns a thread with n top-level comments,
+// each of which has n replies, recursively, up to the given depth.
+func newThread(depth, n int) *Thread {
+	var newComments func(depth int) []*Comment
+	newComments = func(depth int) []*Comment {
+		if depth == 0 {
+			return nil
+		}
+		comments := make([]*Comment, n)
+		for i := range comments {
+			comments[i] = &Comment{
+				Author:  fmt.Sprintf("user%d", i),
+				Date:    "2025-01-02",
+				Body:    "I <3 templates & " + strings.Repeat("so should you. ", 3),
+				Replies: newComments(depth - 1),
+			}
+		}
+		return comments
+	}
+	return &Thread{
+		Title:    "Discussion: <templates>",
+		Comments: newComments(depth),
+	}
+}
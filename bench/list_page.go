@@ -0,0 +1,50 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package bench
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// listPageSizeHint is the number of bytes of text that listPage always writes.
+// It can be used to size buffers for the output.
+const listPageSizeHint = 108
+
+func listPage(buf *bytes.Buffer, data any) {
+	buf.Grow(listPageSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	buf.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<title>")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Query")))
+	buf.WriteString(" - Search</title>\n</head>\n<body>\n<h1>Results for ")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Query")))
+	buf.WriteString("</h1>\n")
+	for it := stack.Iterate(stack.Lookup("Results")); it.Next(); {
+		stack.Push(it.Value())
+		buf.WriteString("<div class=\"result\">\n<a href=\"")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("URL")))
+		buf.WriteString("\">")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Title")))
+		buf.WriteString("</a>\n<p>")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Snippet")))
+		buf.WriteString("</p>\n<ul>\n")
+		for it := stack.Iterate(stack.Lookup("Tags")); it.Next(); {
+			stack.Push(it.Value())
+			buf.WriteString("<li>")
+			m.EscapeQuotes.Escape(buf, m.ToString(stack.Top()))
+			buf.WriteString("</li>\n")
+			stack.Pop()
+		}
+		buf.WriteString("</ul>\n</div>\n")
+		stack.Pop()
+	}
+	if m.IsFalsyOrEmptyList(stack.Lookup("Results")) {
+		buf.WriteString("<p>No results.</p>\n")
+	}
+	buf.WriteString("</body>\n</html>\n")
+}
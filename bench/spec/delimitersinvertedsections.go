@@ -0,0 +1,35 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// delimitersInvertedSectionsSizeHint is the number of bytes of text that delimitersInvertedSections always writes.
+// It can be used to size buffers for the output.
+const delimitersInvertedSectionsSizeHint = 5
+
+func delimitersInvertedSections(buf *bytes.Buffer, data any) {
+	buf.Grow(delimitersInvertedSectionsSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	buf.WriteString("[\n")
+	if m.IsFalsyOrEmptyList(stack.Lookup("section")) {
+		buf.WriteString("  ")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("data")))
+		buf.WriteString("\n  |data|\n")
+	}
+	buf.WriteString("\n")
+	if m.IsFalsyOrEmptyList(stack.Lookup("section")) {
+		buf.WriteString("  {{data}}\n  ")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("data")))
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]\n")
+}
@@ -0,0 +1,34 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// delimitersPartialInheritenceSizeHint is the number of bytes of text that delimitersPartialInheritence always writes.
+// It can be used to size buffers for the output.
+const delimitersPartialInheritenceSizeHint = 14
+
+func delimitersPartialInheritence(buf *bytes.Buffer, data any) {
+	buf.Grow(delimitersPartialInheritenceSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	buf.WriteString("[ ")
+	_delimitersPartialInheritence_p0(buf, "", stack, m.Blocks{})
+	buf.WriteString(" ]\n[ ")
+	_delimitersPartialInheritence_p0(buf, "", stack, m.Blocks{})
+	buf.WriteString(" ]\n")
+}
+
+func _delimitersPartialInheritence_p0(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	buf.WriteString(".")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("value")))
+	buf.WriteString(".")
+}
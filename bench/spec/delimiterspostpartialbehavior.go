@@ -0,0 +1,37 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// delimitersPostPartialBehaviorSizeHint is the number of bytes of text that delimitersPostPartialBehavior always writes.
+// It can be used to size buffers for the output.
+const delimitersPostPartialBehaviorSizeHint = 29
+
+func delimitersPostPartialBehavior(buf *bytes.Buffer, data any) {
+	buf.Grow(delimitersPostPartialBehaviorSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	buf.WriteString("[ ")
+	_delimitersPostPartialBehavior_p0(buf, "", stack, m.Blocks{})
+	buf.WriteString(" ]\n[ .")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("value")))
+	buf.WriteString(".  .|value|. ]\n")
+}
+
+func _delimitersPostPartialBehavior_p0(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	buf.WriteString(".")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("value")))
+	buf.WriteString(". ")
+	buf.WriteString(" .")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("value")))
+	buf.WriteString(".")
+}
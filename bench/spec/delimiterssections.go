@@ -0,0 +1,39 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// delimitersSectionsSizeHint is the number of bytes of text that delimitersSections always writes.
+// It can be used to size buffers for the output.
+const delimitersSectionsSizeHint = 5
+
+func delimitersSections(buf *bytes.Buffer, data any) {
+	buf.Grow(delimitersSectionsSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	buf.WriteString("[\n")
+	for it := stack.Iterate(stack.Lookup("section")); it.Next(); {
+		stack.Push(it.Value())
+		buf.WriteString("  ")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("data")))
+		buf.WriteString("\n  |data|\n")
+		stack.Pop()
+	}
+	buf.WriteString("\n")
+	for it := stack.Iterate(stack.Lookup("section")); it.Next(); {
+		stack.Push(it.Value())
+		buf.WriteString("  {{data}}\n  ")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("data")))
+		buf.WriteString("\n")
+		stack.Pop()
+	}
+	buf.WriteString("]\n")
+}
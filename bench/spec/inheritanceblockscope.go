@@ -0,0 +1,53 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// inheritanceBlockScopeSizeHint is the number of bytes of text that inheritanceBlockScope always writes.
+// It can be used to size buffers for the output.
+const inheritanceBlockScopeSizeHint = 0
+
+func inheritanceBlockScope(buf *bytes.Buffer, data any) {
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	_inheritanceBlockScope_p0(buf, "", stack, stack.PushBlocks(m.Blocks{}, _inheritanceBlockScope_t0))
+	stack.PopBlocks()
+}
+
+func _inheritanceBlockScope_p0(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	for it := stack.Iterate(stack.Lookup("nested")); it.Next(); {
+		stack.Push(it.Value())
+		if b, env := stack.Block(blocks, "block"); b != nil {
+			b(buf, "", stack, env)
+		} else {
+			buf.WriteString("You say ")
+			m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("fruit")))
+			buf.WriteString(".")
+		}
+		stack.Pop()
+	}
+}
+
+func _inheritanceBlockScope_b0(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	buf.WriteString("I say ")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("fruit")))
+	buf.WriteString(".")
+}
+
+func _inheritanceBlockScope_t0(name string) m.BlockFunc {
+	switch name {
+	case "block":
+		return _inheritanceBlockScope_b0
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// inheritanceMustacheInjectionSizeHint is the number of bytes of text that inheritanceMustacheInjection always writes.
+// It can be used to size buffers for the output.
+const inheritanceMustacheInjectionSizeHint = 1
+
+func inheritanceMustacheInjection(buf *bytes.Buffer, data any) {
+	buf.Grow(inheritanceMustacheInjectionSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	buf.WriteString("default ")
+	for it := stack.Iterate(stack.Lookup("bar")); it.Next(); {
+		stack.Push(it.Value())
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("baz")))
+		stack.Pop()
+	}
+	buf.WriteString(" content")
+	buf.WriteString("\n")
+}
@@ -0,0 +1,28 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// inheritanceNegativeSectionsSizeHint is the number of bytes of text that inheritanceNegativeSections always writes.
+// It can be used to size buffers for the output.
+const inheritanceNegativeSectionsSizeHint = 1
+
+func inheritanceNegativeSections(buf *bytes.Buffer, data any) {
+	buf.Grow(inheritanceNegativeSectionsSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	buf.WriteString("default ")
+	if m.IsFalsyOrEmptyList(stack.Lookup("bar")) {
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("baz")))
+	}
+	buf.WriteString(" content")
+	buf.WriteString("\n")
+}
@@ -0,0 +1,31 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// interpolationDottedNamesBasicInterpolationSizeHint is the number of bytes of text that interpolationDottedNamesBasicInterpolation always writes.
+// It can be used to size buffers for the output.
+const interpolationDottedNamesBasicInterpolationSizeHint = 8
+
+func interpolationDottedNamesBasicInterpolation(buf *bytes.Buffer, data any) {
+	buf.Grow(interpolationDottedNamesBasicInterpolationSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	buf.WriteString("\"")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("person", "name")))
+	buf.WriteString("\" == \"")
+	for it := stack.Iterate(stack.Lookup("person")); it.Next(); {
+		stack.Push(it.Value())
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("name")))
+		stack.Pop()
+	}
+	buf.WriteString("\"")
+}
@@ -0,0 +1,31 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// invertedDoubledSizeHint is the number of bytes of text that invertedDoubled always writes.
+// It can be used to size buffers for the output.
+const invertedDoubledSizeHint = 3
+
+func invertedDoubled(buf *bytes.Buffer, data any) {
+	buf.Grow(invertedDoubledSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	if m.IsFalsyOrEmptyList(stack.Lookup("bool")) {
+		buf.WriteString("* first\n")
+	}
+	buf.WriteString("* ")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+	buf.WriteString("\n")
+	if m.IsFalsyOrEmptyList(stack.Lookup("bool")) {
+		buf.WriteString("* third\n")
+	}
+}
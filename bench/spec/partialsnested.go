@@ -0,0 +1,38 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// partialsNestedSizeHint is the number of bytes of text that partialsNested always writes.
+// It can be used to size buffers for the output.
+const partialsNestedSizeHint = 4
+
+func partialsNested(buf *bytes.Buffer, data any) {
+	buf.Grow(partialsNestedSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	_partialsNested_p0(buf, "", stack, m.Blocks{})
+}
+
+func _partialsNested_p0(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	buf.WriteString("*")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("a")))
+	buf.WriteString(" ")
+	_partialsNested_p1(buf, indent, stack, m.Blocks{})
+	buf.WriteString("*")
+}
+
+func _partialsNested_p1(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("b")))
+	buf.WriteString("!")
+}
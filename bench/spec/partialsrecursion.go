@@ -0,0 +1,35 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// partialsRecursionSizeHint is the number of bytes of text that partialsRecursion always writes.
+// It can be used to size buffers for the output.
+const partialsRecursionSizeHint = 2
+
+func partialsRecursion(buf *bytes.Buffer, data any) {
+	buf.Grow(partialsRecursionSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	_partialsRecursion_p0(buf, "", stack, m.Blocks{})
+}
+
+func _partialsRecursion_p0(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("content")))
+	buf.WriteString("<")
+	for it := stack.Iterate(stack.Lookup("nodes")); it.Next(); {
+		stack.Push(it.Value())
+		_partialsRecursion_p0(buf, indent, stack, m.Blocks{})
+		stack.Pop()
+	}
+	buf.WriteString(">")
+}
@@ -0,0 +1,113 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// sectionsDeeplyNestedContextsSizeHint is the number of bytes of text that sectionsDeeplyNestedContexts always writes.
+// It can be used to size buffers for the output.
+const sectionsDeeplyNestedContextsSizeHint = 0
+
+func sectionsDeeplyNestedContexts(buf *bytes.Buffer, data any) {
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	for it := stack.Iterate(stack.Lookup("a")); it.Next(); {
+		stack.Push(it.Value())
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+		buf.WriteString("\n")
+		for it := stack.Iterate(stack.Lookup("b")); it.Next(); {
+			stack.Push(it.Value())
+			m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+			m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+			m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+			buf.WriteString("\n")
+			for it := stack.Iterate(stack.Lookup("c")); it.Next(); {
+				stack.Push(it.Value())
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("three")))
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+				buf.WriteString("\n")
+				for it := stack.Iterate(stack.Lookup("d")); it.Next(); {
+					stack.Push(it.Value())
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("three")))
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("four")))
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("three")))
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+					buf.WriteString("\n")
+					for it := stack.Iterate(stack.Lookup("five")); it.Next(); {
+						stack.Push(it.Value())
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("three")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("four")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("five")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("four")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("three")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+						buf.WriteString("\n")
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("three")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("four")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Top()))
+						buf.WriteString("6")
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Top()))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("four")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("three")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+						buf.WriteString("\n")
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("three")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("four")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("five")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("four")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("three")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+						m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+						buf.WriteString("\n")
+						stack.Pop()
+					}
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("three")))
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("four")))
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("three")))
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+					m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+					buf.WriteString("\n")
+					stack.Pop()
+				}
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("three")))
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+				buf.WriteString("\n")
+				stack.Pop()
+			}
+			m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+			m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+			m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+			buf.WriteString("\n")
+			stack.Pop()
+		}
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("one")))
+		buf.WriteString("\n")
+		stack.Pop()
+	}
+}
@@ -0,0 +1,35 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// sectionsDoubledSizeHint is the number of bytes of text that sectionsDoubled always writes.
+// It can be used to size buffers for the output.
+const sectionsDoubledSizeHint = 3
+
+func sectionsDoubled(buf *bytes.Buffer, data any) {
+	buf.Grow(sectionsDoubledSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	for it := stack.Iterate(stack.Lookup("bool")); it.Next(); {
+		stack.Push(it.Value())
+		buf.WriteString("* first\n")
+		stack.Pop()
+	}
+	buf.WriteString("* ")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("two")))
+	buf.WriteString("\n")
+	for it := stack.Iterate(stack.Lookup("bool")); it.Next(); {
+		stack.Push(it.Value())
+		buf.WriteString("* third\n")
+		stack.Pop()
+	}
+}
@@ -0,0 +1,40 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// sectionsListContextsSizeHint is the number of bytes of text that sectionsListContexts always writes.
+// It can be used to size buffers for the output.
+const sectionsListContextsSizeHint = 0
+
+func sectionsListContexts(buf *bytes.Buffer, data any) {
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	for it := stack.Iterate(stack.Lookup("tops")); it.Next(); {
+		stack.Push(it.Value())
+		for it := stack.Iterate(stack.Lookup("middles")); it.Next(); {
+			stack.Push(it.Value())
+			m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("tname", "lower")))
+			m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("mname")))
+			buf.WriteString(".")
+			for it := stack.Iterate(stack.Lookup("bottoms")); it.Next(); {
+				stack.Push(it.Value())
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("tname", "upper")))
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("mname")))
+				m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("bname")))
+				buf.WriteString(".")
+				stack.Pop()
+			}
+			stack.Pop()
+		}
+		stack.Pop()
+	}
+}
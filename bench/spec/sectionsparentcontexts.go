@@ -0,0 +1,33 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package spec
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// sectionsParentContextsSizeHint is the number of bytes of text that sectionsParentContexts always writes.
+// It can be used to size buffers for the output.
+const sectionsParentContextsSizeHint = 2
+
+func sectionsParentContexts(buf *bytes.Buffer, data any) {
+	buf.Grow(sectionsParentContextsSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	buf.WriteString("\"")
+	for it := stack.Iterate(stack.Lookup("sec")); it.Next(); {
+		stack.Push(it.Value())
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("a")))
+		buf.WriteString(", ")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("b")))
+		buf.WriteString(", ")
+		m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("c", "d")))
+		stack.Pop()
+	}
+	buf.WriteString("\"")
+}
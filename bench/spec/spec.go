@@ -0,0 +1,24 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+// Package spec benchmarks the Go functions that mustache-codegen generates
+// for the tests in the Mustache specification.
+//
+// Run the benchmarks with:
+//
+//	go test -bench=. ./bench/spec
+//
+// Each benchmark reports the allocations per render.
+package spec
+
+//go:generate go test ../../cmd/mustache-codegen -run=TestSpecBench -update-spec-bench
+
+import "bytes"
+
+// testCase is a specification test with its generated function.
+type testCase struct {
+	suite, name string
+	render      func(buf *bytes.Buffer, data any)
+	// data is the JSON data of the test.
+	data string
+}
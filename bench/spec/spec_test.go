@@ -0,0 +1,27 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package spec
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func BenchmarkSpec(b *testing.B) {
+	for _, test := range tests {
+		b.Run(test.suite+"/"+test.name, func(b *testing.B) {
+			var data any
+			if err := json.Unmarshal([]byte(test.data), &data); err != nil {
+				b.Fatal(err)
+			}
+			buf := new(bytes.Buffer)
+			b.ReportAllocs()
+			for range b.N {
+				buf.Reset()
+				test.render(buf, data)
+			}
+		})
+	}
+}
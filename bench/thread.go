@@ -0,0 +1,57 @@
+// Code generated by mustache-codegen. DO NOT EDIT.
+
+package bench
+
+import (
+	"bytes"
+
+	m "github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+// Ignore unused imports.
+var _ = m.Lookup
+
+// threadSizeHint is the number of bytes of text that thread always writes.
+// It can be used to size buffers for the output.
+const threadSizeHint = 46
+
+func thread(buf *bytes.Buffer, data any) {
+	buf.Grow(threadSizeHint)
+	stack := m.GetStack(data)
+	defer m.PutStack(stack)
+	buf.WriteString("<section class=\"thread\">\n<h2>")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Title")))
+	buf.WriteString("</h2>\n")
+	for it := stack.Iterate(stack.Lookup("Comments")); it.Next(); {
+		stack.Push(it.Value())
+		_thread_p0(buf, "", stack, m.Blocks{})
+		stack.Pop()
+	}
+	buf.WriteString("</section>\n")
+}
+
+func _thread_p0(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	buf.WriteString("<div class=\"comment\">\n")
+	_thread_p1(buf, indent, stack, m.Blocks{})
+	buf.WriteString(indent)
+	buf.WriteString("<p>")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Body")))
+	buf.WriteString("</p>\n")
+	for it := stack.Iterate(stack.Lookup("Replies")); it.Next(); {
+		stack.Push(it.Value())
+		_thread_p0(buf, indent, stack, m.Blocks{})
+		stack.Pop()
+	}
+	buf.WriteString(indent)
+	buf.WriteString("</div>\n")
+}
+
+func _thread_p1(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {
+	buf.WriteString(indent)
+	buf.WriteString("<span class=\"author\">")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Author")))
+	buf.WriteString("</span> <time>")
+	m.EscapeQuotes.Escape(buf, m.ToString(stack.Lookup("Date")))
+	buf.WriteString("</time>\n")
+}
@@ -0,0 +1,467 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kagisearch/mustache-codegen/go/mustache"
+	"github.com/kagisearch/mustache-codegen/go/mustache/interp"
+)
+
+// differentialSeeds is the number of random test cases
+// that TestGoJSDifferential checks
+// and that seed FuzzGoJSDifferential's corpus.
+const differentialSeeds = 100
+
+// TestGoJSDifferential verifies that generated Go and JavaScript code
+// produce the same output for random templates and data.
+// All test cases are compiled into a single Go program and a single JavaScript program
+// so that the test only has to build and run each once.
+func TestGoJSDifferential(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping for -short")
+	}
+	goPath, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("Cannot find go(?!):", err)
+	}
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Cannot find node:", err)
+	}
+
+	tests := make([]*testCase, differentialSeeds)
+	for i := range tests {
+		tests[i] = randomTestCase(uint64(i))
+	}
+	goDir := t.TempDir()
+	jsDir := t.TempDir()
+	goMain := new(bytes.Buffer)
+	goMain.WriteString("package main\n" +
+		"import (\"bytes\"; \"encoding/json\"; \"os\")\n" +
+		"var templates = []func(*bytes.Buffer, any){\n")
+	jsMain := new(bytes.Buffer)
+	jsMain.WriteString("const templates = [\n")
+	for i, test := range tests {
+		load := func(name string) (string, error) {
+			return test.Partials[name], nil
+		}
+		escaper := differentialEscaper(uint64(i))
+		funcName := fmt.Sprintf("T%d", i)
+		goSource, err := compileGo(funcName, test.Template, load, &goOptions{packageName: "main", funcName: funcName, escaper: escaper})
+		if err != nil {
+			t.Fatalf("%s: compile Go: %v", test.Name, err)
+		}
+		if err := os.WriteFile(filepath.Join(goDir, fmt.Sprintf("t%d.go", i)), goSource, 0o666); err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(goMain, "%s,\n", funcName)
+
+		js, err := compileJS("template", test.Template, load, &jsOptions{escaper: escaper})
+		if err != nil {
+			t.Fatalf("%s: compile JS: %v", test.Name, err)
+		}
+		if err := os.WriteFile(filepath.Join(jsDir, fmt.Sprintf("t%d.mjs", i)), js, 0o666); err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(jsMain, "(await import('./t%d.mjs')).default,\n", i)
+	}
+	goMain.WriteString("}\n" +
+		"func main() {\n" +
+		"var data []any\n" +
+		"if err := json.NewDecoder(os.Stdin).Decode(&data); err != nil { panic(err) }\n" +
+		"var out []string\n" +
+		"for i, t := range templates { buf := new(bytes.Buffer); t(buf, data[i]); out = append(out, buf.String()) }\n" +
+		"json.NewEncoder(os.Stdout).Encode(out)\n" +
+		"}\n")
+	if err := os.WriteFile(filepath.Join(goDir, "main.go"), goMain.Bytes(), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	writeGoModule(t, goPath, goDir)
+	jsMain.WriteString("]\n" +
+		"let data = ''\n" +
+		"for await (const chunk of process.stdin) data += chunk\n" +
+		"data = JSON.parse(data)\n" +
+		"process.stdout.write(JSON.stringify(templates.map((t, i) => t(data[i]))))\n")
+	if err := os.WriteFile(filepath.Join(jsDir, "main.mjs"), jsMain.Bytes(), 0o666); err != nil {
+		t.Fatal(err)
+	}
+
+	var allData []json.RawMessage
+	for _, test := range tests {
+		allData = append(allData, test.Data)
+	}
+	input, err := json.Marshal(allData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runAll := func(dir string, name string, args ...string) []string {
+		t.Helper()
+		c := exec.Command(name, args...)
+		c.Dir = dir
+		c.Stdin = bytes.NewReader(input)
+		c.Stderr = os.Stderr
+		stdout, err := c.Output()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var out []string
+		if err := json.Unmarshal(stdout, &out); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(out) != len(tests) {
+			t.Fatalf("%s: got %d outputs; want %d", name, len(out), len(tests))
+		}
+		return out
+	}
+	goOutputs := runAll(goDir, goPath, "run", ".")
+	jsOutputs := runAll(jsDir, nodePath, "main.mjs")
+
+	for i, test := range tests {
+		if goOutputs[i] != jsOutputs[i] {
+			t.Errorf("%s: Go output:\n%q\nJavaScript output:\n%q\n%s", test.Name, goOutputs[i], jsOutputs[i], describeTestCase(test))
+		}
+	}
+}
+
+// FuzzGoJSDifferential verifies that the Go and JavaScript backends
+// produce the same output for random templates and data.
+// The fuzzer's input is a seed for [randomTestCase].
+// For speed, Go output is produced by the interpreter
+// (which TestInterpreter checks against generated Go code)
+// and JavaScript code is evaluated by a single long-running node process.
+func FuzzGoJSDifferential(f *testing.F) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		f.Skip("Cannot find node:", err)
+	}
+	js, err := startJSRenderer(nodePath)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Cleanup(func() { js.close() })
+
+	for seed := range uint64(differentialSeeds) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, seed uint64) {
+		test := randomTestCase(seed)
+		escaper := differentialEscaper(seed)
+		load := func(name string) (string, error) {
+			return test.Partials[name], nil
+		}
+
+		tmpl, err := interp.Parse(test.Template, load)
+		if err != nil {
+			t.Fatalf("parse: %v\n%s", err, describeTestCase(test))
+		}
+		var data any
+		if err := json.Unmarshal(test.Data, &data); err != nil {
+			t.Fatal(err)
+		}
+		buf := new(bytes.Buffer)
+		tmpl.RenderOptions(buf, data, &interp.RenderOptions{Escaper: escaper})
+		goOutput := buf.String()
+
+		code, err := compileJS("template", test.Template, load, &jsOptions{escaper: escaper})
+		if err != nil {
+			t.Fatalf("compile JS: %v\n%s", err, describeTestCase(test))
+		}
+		jsOutput, err := js.render(code, test.Data)
+		if err != nil {
+			t.Fatalf("JavaScript: %v\n%s", err, describeTestCase(test))
+		}
+
+		if goOutput != jsOutput {
+			t.Errorf("Go output:\n%q\nJavaScript output:\n%q\n%s", goOutput, jsOutput, describeTestCase(test))
+		}
+	})
+}
+
+// differentialEscaper returns the escaper to use for the test case with the given seed,
+// alternating between all escapers.
+func differentialEscaper(seed uint64) mustache.Escaper {
+	return mustache.Escaper(seed % uint64(len(goEscapers)))
+}
+
+func describeTestCase(test *testCase) string {
+	sb := new(strings.Builder)
+	fmt.Fprintf(sb, "template:\n%s\n", test.Template)
+	for name, source := range test.Partials {
+		fmt.Fprintf(sb, "partial %s:\n%s\n", name, source)
+	}
+	fmt.Fprintf(sb, "data:\n%s", test.Data)
+	return sb.String()
+}
+
+// writeGoModule writes a go.mod file into dir
+// that resolves this module to the working copy
+// and then runs go mod tidy.
+func writeGoModule(tb testing.TB, goPath string, dir string) {
+	tb.Helper()
+	currentDir, err := os.Getwd()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	goMod := "module foo\n" +
+		"require github.com/kagisearch/mustache-codegen v0.1.0\n" +
+		"replace github.com/kagisearch/mustache-codegen => " +
+		filepath.Dir(filepath.Dir(currentDir)) + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o666); err != nil {
+		tb.Fatal(err)
+	}
+
+	c := exec.Command(goPath, "mod", "tidy")
+	c.Dir = dir
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		tb.Fatal("go mod tidy:", err)
+	}
+}
+
+// jsRenderer is a node process that renders compiled JavaScript templates.
+type jsRenderer struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+}
+
+// jsRendererScript reads one JSON request per line from stdin,
+// imports the request's code as a module,
+// and writes the rendered output as one JSON response per line.
+const jsRendererScript = `
+import {createInterface} from 'node:readline'
+for await (const line of createInterface({input: process.stdin})) {
+	const {code, data} = JSON.parse(line)
+	let response
+	try {
+		const t = (await import('data:text/javascript;base64,' + Buffer.from(code).toString('base64'))).default
+		response = {output: t(data)}
+	} catch (e) {
+		response = {error: String(e)}
+	}
+	process.stdout.write(JSON.stringify(response) + '\n')
+}
+`
+
+func startJSRenderer(nodePath string) (*jsRenderer, error) {
+	c := exec.Command(nodePath, "--input-type=module", "-e", jsRendererScript)
+	c.Stderr = os.Stderr
+	stdin, err := c.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := c.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Start(); err != nil {
+		return nil, err
+	}
+	return &jsRenderer{cmd: c, stdin: stdin, stdout: bufio.NewReader(stdout)}, nil
+}
+
+// render renders the compiled template code with the given JSON data.
+func (r *jsRenderer) render(code []byte, data json.RawMessage) (string, error) {
+	request, err := json.Marshal(struct {
+		Code string          `json:"code"`
+		Data json.RawMessage `json:"data"`
+	}{string(code), data})
+	if err != nil {
+		return "", err
+	}
+	if _, err := r.stdin.Write(append(request, '\n')); err != nil {
+		return "", err
+	}
+	line, err := r.stdout.ReadBytes('\n')
+	if err != nil {
+		return "", err
+	}
+	var response struct {
+		Output string `json:"output"`
+		Error  string `json:"error"`
+	}
+	if err := json.Unmarshal(line, &response); err != nil {
+		return "", err
+	}
+	if response.Error != "" {
+		return "", fmt.Errorf("%s\ngenerated code:\n%s", response.Error, generatedJS(code))
+	}
+	return response.Output, nil
+}
+
+func (r *jsRenderer) close() error {
+	r.stdin.Close()
+	return r.cmd.Wait()
+}
+
+// randomTestCase returns a valid template, partials, and data
+// generated from the given seed.
+// The test case has no expected output:
+// it is meant to be rendered by multiple backends and compared.
+//
+// The generated data uses a fixed set of keys,
+// each of which always refers to the same kind of value,
+// so that the template only interpolates strings, numbers, booleans, and null.
+// Other values (like lists) are formatted differently by each language.
+// Numbers are limited to a range that both languages format without an exponent.
+func randomTestCase(seed uint64) *testCase {
+	g := &testCaseGenerator{r: rand.New(rand.NewPCG(seed, seed))}
+	test := &testCase{
+		Name:     fmt.Sprintf("seed%d", seed),
+		Partials: make(map[string]string),
+	}
+	for _, name := range randomPartialNames {
+		test.Partials[name] = g.tags(2, false, false)
+	}
+	test.Partials[randomLayoutName] = g.tags(2, false, true)
+	test.Template = g.tags(3, true, true)
+
+	data := g.scalars()
+	if g.r.IntN(4) > 0 {
+		data["obj"] = g.scalars()
+	}
+	list := []any{}
+	for range g.r.IntN(4) {
+		list = append(list, g.scalars())
+	}
+	data["list"] = list
+	strs := []any{}
+	for range g.r.IntN(4) {
+		strs = append(strs, g.pick(randomStrings))
+	}
+	data["strs"] = strs
+	data["empty"] = []any{}
+	var err error
+	test.Data, err = json.Marshal(data)
+	if err != nil {
+		panic(err)
+	}
+	return test
+}
+
+var (
+	randomScalarKeys   = []string{"str", "num", "flag", "nil"}
+	randomSectionNames = []string{"obj", "list", "strs", "empty", "missing", "obj.str", "obj.flag"}
+	randomStrings      = []string{"", "plain", "<b>bold</b>", "a & b", `say "hi"`, "it's", "two\nlines", "  padded  "}
+	randomLiterals     = []string{"", " ", "  ", "\n", "\r\n", "\t", "text", "<p>", "a & b", "{{! comment }}", "{{! multi\nline }}"}
+	randomPartialNames = []string{"part1", "part2"}
+	randomBlockNames   = []string{"title", "body"}
+)
+
+const randomLayoutName = "layout"
+
+type testCaseGenerator struct {
+	r *rand.Rand
+}
+
+func (g *testCaseGenerator) pick(list []string) string {
+	return list[g.r.IntN(len(list))]
+}
+
+// scalars returns a JSON object with a random subset of randomScalarKeys.
+func (g *testCaseGenerator) scalars() map[string]any {
+	m := make(map[string]any)
+	for _, k := range randomScalarKeys {
+		if g.r.IntN(3) == 0 {
+			continue
+		}
+		switch k {
+		case "str":
+			m[k] = g.pick(randomStrings)
+		case "num":
+			if g.r.IntN(2) == 0 {
+				m[k] = g.r.IntN(200) - 100
+			} else {
+				m[k] = float64(g.r.IntN(2000)-1000) / 8
+			}
+		case "flag":
+			m[k] = g.r.IntN(2) == 0
+		case "nil":
+			m[k] = nil
+		}
+	}
+	return m
+}
+
+// tags returns a random list of tags nested no more than depth sections deep.
+// If partials is true, the list may contain partial and parent tags.
+// If blocks is true, the list may contain block tags.
+func (g *testCaseGenerator) tags(depth int, partials, blocks bool) string {
+	return g.tagList(depth, partials, blocks, false)
+}
+
+// tagList is like tags, but if dotScalar is true,
+// the top of the context stack is known to be a scalar value
+// that can be interpolated with {{.}}.
+func (g *testCaseGenerator) tagList(depth int, partials, blocks, dotScalar bool) string {
+	sb := new(strings.Builder)
+	for range g.r.IntN(6) {
+		switch n := g.r.IntN(10); {
+		case n < 4:
+			sb.WriteString(g.pick(randomLiterals))
+		case n < 6:
+			name := g.pick(randomScalarKeys)
+			switch g.r.IntN(4) {
+			case 0:
+				name = "obj." + name
+			case 1:
+				if dotScalar {
+					name = "."
+				}
+			}
+			switch g.r.IntN(4) {
+			case 0:
+				fmt.Fprintf(sb, "{{{%s}}}", name)
+			case 1:
+				fmt.Fprintf(sb, "{{& %s }}", name)
+			default:
+				fmt.Fprintf(sb, "{{%s}}", name)
+			}
+		case n < 8 && depth > 0:
+			// Sections over scalars push a value that can be interpolated.
+			name := g.pick(randomScalarKeys)
+			innerDotScalar := true
+			if g.r.IntN(2) == 0 {
+				name = g.pick(randomSectionNames)
+				innerDotScalar = name == "strs" || strings.HasPrefix(name, "obj.")
+			}
+			if g.r.IntN(3) == 0 {
+				fmt.Fprintf(sb, "{{^%s}}%s{{/%s}}", name, g.tagList(depth-1, partials, blocks, dotScalar), name)
+			} else {
+				fmt.Fprintf(sb, "{{#%s}}%s{{/%s}}", name, g.tagList(depth-1, partials, blocks, innerDotScalar), name)
+			}
+		case n == 8 && partials:
+			if g.r.IntN(2) == 0 {
+				fmt.Fprintf(sb, "{{>%s}}", g.pick(randomPartialNames))
+				break
+			}
+			fmt.Fprintf(sb, "{{<%s}}", randomLayoutName)
+			for range g.r.IntN(3) {
+				if g.r.IntN(3) == 0 {
+					sb.WriteString(g.pick(randomLiterals))
+				}
+				name := g.pick(randomBlockNames)
+				fmt.Fprintf(sb, "{{$%s}}%s{{/%s}}", name, g.tagList(depth-1, partials, blocks, dotScalar), name)
+			}
+			fmt.Fprintf(sb, "{{/%s}}", randomLayoutName)
+		case n == 9 && blocks && depth > 0:
+			name := g.pick(randomBlockNames)
+			fmt.Fprintf(sb, "{{$%s}}%s{{/%s}}", name, g.tagList(depth-1, partials, blocks, dotScalar), name)
+		}
+	}
+	return sb.String()
+}
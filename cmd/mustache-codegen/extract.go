@@ -0,0 +1,142 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kagisearch/mustache-codegen/internal/syntax"
+)
+
+// message is a translatable message found in templates.
+type message struct {
+	Message string `json:"message"`
+	// Locations is the list of places the message appears in
+	// as "path:line".
+	Locations []string `json:"locations"`
+}
+
+// extractMain runs the extract command,
+// which writes a catalog of the translatable messages in templates.
+func extractMain(args []string) {
+	fset := flag.FlagSet{Usage: func() {}}
+	format := fset.String("format", "pot", "catalog `format`: pot (a gettext template) or json")
+	outputFile := fset.String("o", "", "output `file`")
+	if err := fset.Parse(args); err != nil || fset.NArg() == 0 {
+		fmt.Fprintf(fset.Output(), "usage: %s extract [options] TEMPLATE...\n\n", programName)
+		fset.PrintDefaults()
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		os.Exit(64) // EX_USAGE
+	}
+
+	var messages []*message
+	for _, fname := range fset.Args() {
+		source, err := os.ReadFile(fname)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
+			os.Exit(1)
+		}
+		messages, err = extractMessages(messages, filepath.ToSlash(fname), string(source))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s %v\n", programName, fname, err)
+			os.Exit(1)
+		}
+	}
+
+	var output []byte
+	switch *format {
+	case "pot":
+		output = formatPOT(messages)
+	case "json":
+		var err error
+		output, err = json.MarshalIndent(messages, "", "\t")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
+			os.Exit(1)
+		}
+		output = append(output, '\n')
+	default:
+		fmt.Fprintf(os.Stderr, "%s: unknown -format=%s\n", programName, *format)
+		os.Exit(64) // EX_USAGE
+	}
+	writeOutput(*outputFile, output)
+}
+
+// extractMessages appends the translatable messages in the template
+// to messages, merging the locations of messages that appear more than once.
+func extractMessages(messages []*message, path string, source string) ([]*message, error) {
+	// Recognize filters so that templates that use them can be extracted.
+	tags, err := syntax.ParseOptions(source, &syntax.Options{Filters: true, I18n: true})
+	if err != nil {
+		return nil, err
+	}
+	for t := range syntax.WalkTags(tags) {
+		if t.Type != syntax.Translation {
+			continue
+		}
+		loc := fmt.Sprintf("%s:%d", path, t.Line)
+		found := false
+		for _, msg := range messages {
+			if msg.Message == t.S {
+				msg.Locations = append(msg.Locations, loc)
+				found = true
+				break
+			}
+		}
+		if !found {
+			messages = append(messages, &message{Message: t.S, Locations: []string{loc}})
+		}
+	}
+	return messages, nil
+}
+
+// formatPOT formats messages as a gettext template.
+func formatPOT(messages []*message) []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteString("# Translatable messages extracted by mustache-codegen.\n")
+	buf.WriteString("msgid \"\"\n")
+	buf.WriteString("msgstr \"\"\n")
+	buf.WriteString("\"Content-Type: text/plain; charset=UTF-8\\n\"\n")
+	for _, msg := range messages {
+		buf.WriteString("\n")
+		for _, loc := range msg.Locations {
+			fmt.Fprintf(buf, "#: %s\n", loc)
+		}
+		fmt.Fprintf(buf, "msgid %s\n", poString(msg.Message))
+		buf.WriteString("msgstr \"\"\n")
+	}
+	return buf.Bytes()
+}
+
+// poString returns s as a PO file string.
+// Strings with newlines before their end are split into a string per line.
+func poString(s string) string {
+	quote := func(s string) string {
+		r := strings.NewReplacer("\\", `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+		return `"` + r.Replace(s) + `"`
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= 1 {
+		return quote(s)
+	}
+	sb := new(strings.Builder)
+	sb.WriteString(`""`)
+	for _, line := range lines {
+		sb.WriteString("\n")
+		sb.WriteString(quote(line))
+	}
+	return sb.String()
+}
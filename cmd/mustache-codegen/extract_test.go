@@ -0,0 +1,57 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractMessages(t *testing.T) {
+	messages, err := extractMessages(nil, "a.mustache", "{{#_i18n}}Hello, {{name}}!{{/_i18n}}\n{{#items}}\n{{#_i18n}}Item{{/_i18n}}\n{{/items}}\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages, err = extractMessages(messages, "dir/b.mustache", "\n{{#_i18n}}Item{{/_i18n}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*message{
+		{Message: "Hello, {{name}}!", Locations: []string{"a.mustache:1"}},
+		{Message: "Item", Locations: []string{"a.mustache:3", "dir/b.mustache:2"}},
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("messages = %+v; want %+v", messages, want)
+	}
+
+	if _, err := extractMessages(nil, "bad.mustache", "{{#_i18n}}"); err == nil {
+		t.Error("extractMessages did not return an error for an unclosed section")
+	}
+}
+
+func TestFormatPOT(t *testing.T) {
+	got := string(formatPOT([]*message{
+		{Message: `Say "hi"`, Locations: []string{"a.mustache:1", "b.mustache:2"}},
+		{Message: "One\nTwo\n", Locations: []string{"a.mustache:3"}},
+	}))
+	const want = `# Translatable messages extracted by mustache-codegen.
+msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+
+#: a.mustache:1
+#: b.mustache:2
+msgid "Say \"hi\""
+msgstr ""
+
+#: a.mustache:3
+msgid ""
+"One\n"
+"Two\n"
+msgstr ""
+`
+	if got != want {
+		t.Errorf("formatPOT(...) =\n%s\nwant:\n%s", got, want)
+	}
+}
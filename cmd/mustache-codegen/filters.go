@@ -0,0 +1,123 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// filterPackage is a Go package or JavaScript module
+// whose functions templates can apply to variables as filters,
+// as in {{price | currency}}.
+type filterPackage struct {
+	// path is the Go import path or JavaScript import specifier of the package.
+	path string
+	// funcs is the set of names of the package's functions
+	// that can be used as filters.
+	funcs map[string]bool
+}
+
+// loadGoFilters finds the exported functions of the Go package with the given import path
+// that can be used as filters: functions with a single parameter of type any
+// and a single result.
+// The import path is resolved in the module that contains dir.
+func loadGoFilters(importPath, dir string) (*filterPackage, error) {
+	ctxt := build.Default
+	ctxt.Dir = dir
+	pkg, err := ctxt.Import(importPath, dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	funcs := make(map[string]bool)
+	for _, name := range pkg.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Type.TypeParams != nil || !fn.Name.IsExported() {
+				continue
+			}
+			params, results := fn.Type.Params, fn.Type.Results
+			if params.NumFields() != 1 || results.NumFields() != 1 || !isGoAnyType(params.List[0].Type) {
+				continue
+			}
+			funcs[fn.Name.Name] = true
+		}
+	}
+	return &filterPackage{path: pkg.ImportPath, funcs: funcs}, nil
+}
+
+// isGoAnyType reports whether expr is the type any or interface{}.
+func isGoAnyType(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name == "any"
+	case *ast.InterfaceType:
+		return expr.Methods.NumFields() == 0
+	default:
+		return false
+	}
+}
+
+// jsExports matches the declarations of a JavaScript module's exports.
+var jsExports = regexp.MustCompile(`(?m)^\s*export\s+(?:(?:async\s+)?function\s*\*?\s*([A-Za-z_$][A-Za-z0-9_$]*)|(?:const|let|var)\s+([A-Za-z_$][A-Za-z0-9_$]*)|\{([^}]*)\})`)
+
+// loadJSFilters finds the exports of the JavaScript module with the given import specifier,
+// which must be a relative path like "./filters.mjs".
+// The path is resolved relative to the directory of the output file.
+func loadJSFilters(specifier, outputFile string) (*filterPackage, error) {
+	if !strings.HasPrefix(specifier, "./") && !strings.HasPrefix(specifier, "../") {
+		return nil, fmt.Errorf("filter module %q is not a relative path", specifier)
+	}
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(outputFile), filepath.FromSlash(specifier)))
+	if err != nil {
+		return nil, err
+	}
+	funcs := make(map[string]bool)
+	for _, m := range jsExports.FindAllStringSubmatch(string(data), -1) {
+		switch {
+		case m[1] != "":
+			funcs[m[1]] = true
+		case m[2] != "":
+			funcs[m[2]] = true
+		default:
+			for _, export := range strings.Split(m[3], ",") {
+				fields := strings.Fields(export)
+				if len(fields) > 0 {
+					funcs[fields[len(fields)-1]] = true
+				}
+			}
+		}
+	}
+	return &filterPackage{path: specifier, funcs: funcs}, nil
+}
+
+// filterFuncName returns the name of the function that implements
+// the filter with the given name in the package,
+// or an error if the package has no such function.
+// Go filter functions are exported; JavaScript filters are named by [jsName].
+func (p *filterPackage) filterFuncName(filter string, js bool) (string, error) {
+	name := goFuncName(filter, true)
+	if js {
+		name = jsName(filter)
+	}
+	if p == nil {
+		return "", fmt.Errorf("unknown filter %s (no filter package)", filter)
+	}
+	if !p.funcs[name] {
+		return "", fmt.Errorf("unknown filter %s (%s has no function %s)", filter, p.path, name)
+	}
+	return name, nil
+}
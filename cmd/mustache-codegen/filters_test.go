@@ -0,0 +1,167 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const filtersTemplate = "{{price | currency}} {{&name | upper}} {{#items}}{{. | currency | upper}};{{/items}}{{missing | currency}}"
+
+func TestCompileGoFilters(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping for -short")
+	}
+	goPath, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("Cannot find go(?!):", err)
+	}
+
+	tempDir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module foo\n",
+		"filters/filters.go": "package filters\n" +
+			"import (\"fmt\"; \"strings\")\n" +
+			"func Currency(v any) any { if v == nil { return \"-\" }; return fmt.Sprintf(\"$%.2f\", v) }\n" +
+			"func Upper(v interface{}) string { return strings.ToUpper(fmt.Sprint(v)) }\n" +
+			"func Lower(s string) string { return strings.ToLower(s) }\n" +
+			"func (T) Method(v any) any { return v }\n" +
+			"type T struct{}\n",
+		"main.go": "package main\n" +
+			"import (\"bytes\"; \"os\")\n" +
+			"func main() {\n" +
+			"buf := new(bytes.Buffer)\n" +
+			"Page(buf, map[string]any{\"price\": 3.5, \"name\": \"<b>x</b>\", \"items\": []any{1.0, 2.5}})\n" +
+			"os.Stdout.Write(buf.Bytes())\n" +
+			"}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filters, err := loadGoFilters("foo/filters", tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]bool{"Currency": true, "Upper": true}; len(filters.funcs) != len(want) || !filters.funcs["Currency"] || !filters.funcs["Upper"] {
+		t.Errorf("filter functions = %v; want %v", filters.funcs, want)
+	}
+	load := func(name string) (string, error) { return "", nil }
+	goSource, err := compileGo("page", filtersTemplate, load, &goOptions{packageName: "main", filters: filters})
+	if err != nil {
+		t.Fatal("compile:", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "page.go"), goSource, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	writeGoModule(t, goPath, tempDir)
+
+	c := exec.Command(goPath, "run", ".")
+	c.Dir = tempDir
+	stdout := new(bytes.Buffer)
+	c.Stdout = stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		t.Fatalf("error: %s\ngenerated code:\n%s", err, goSource)
+	}
+	const want = "$3.50 <B>X</B> $1.00;$2.50;-"
+	if got := stdout.String(); got != want {
+		t.Errorf("output = %q; want %q", got, want)
+	}
+
+	for _, source := range []string{"{{price | lower}}", "{{price | method}}"} {
+		if _, err := compileGo("page", source, load, &goOptions{packageName: "main", filters: filters}); err == nil {
+			t.Errorf("compileGo(%q) did not return an error", source)
+		}
+	}
+	if _, err := compileGo("page", filtersTemplate, load, &goOptions{packageName: "main"}); err == nil {
+		t.Error("compileGo without filters did not return an error")
+	}
+}
+
+func TestCompileJSFilters(t *testing.T) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Cannot find node:", err)
+	}
+
+	tempDir := t.TempDir()
+	const filtersModule = "export function currency(v) { return v === undefined ? '-' : '$' + Number(v).toFixed(2) }\n" +
+		"const upper = async (v) => String(v).toUpperCase()\n" +
+		"export { upper, upper as shout }\n" +
+		"export const len = (v) => String(v).length\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "filters.mjs"), []byte(filtersModule), 0o666); err != nil {
+		t.Fatal(err)
+	}
+	filters, err := loadJSFilters("./filters.mjs", filepath.Join(tempDir, "template.mjs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]bool{"currency": true, "upper": true, "shout": true, "len": true}; !maps.Equal(filters.funcs, want) {
+		t.Errorf("filter functions = %v; want %v", filters.funcs, want)
+	}
+
+	load := func(name string) (string, error) { return "", nil }
+	js, err := compileJS("template", filtersTemplate, load, &jsOptions{stream: true, filters: filters})
+	if err != nil {
+		t.Fatal("compile:", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "template.mjs"), js, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	const script = `import t from './template.mjs'; let out = ''; ` +
+		`for await (const chunk of t({price: 3.5, name: '<b>x</b>', items: [1, 2.5]})) out += chunk; ` +
+		`process.stdout.write(out)`
+	c := exec.Command(nodePath, "--input-type=module", "-e", script)
+	c.Dir = tempDir
+	c.Stderr = os.Stderr
+	out, err := c.Output()
+	if err != nil {
+		t.Fatalf("error: %v\ngenerated code:\n%s", err, generatedJS(js))
+	}
+	const want = "$3.50 <B>X</B> $1.00;$2.50;-"
+	if got := string(out); got != want {
+		t.Errorf("output = %q; want %q", got, want)
+	}
+
+	// Names that are predeclared in Go are fine in JavaScript.
+	js, err = compileJS("template", "{{name | len}}", load, &jsOptions{filters: filters})
+	if err != nil {
+		t.Fatal("compile:", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "template.mjs"), js, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	c = exec.Command(nodePath, "--input-type=module", "-e", `import t from './template.mjs'; process.stdout.write(await t({name: 'abc'}))`)
+	c.Dir = tempDir
+	c.Stderr = os.Stderr
+	out, err = c.Output()
+	if err != nil {
+		t.Fatalf("error: %v\ngenerated code:\n%s", err, generatedJS(js))
+	}
+	if got, want := string(out), "3"; got != want {
+		t.Errorf("output = %q; want %q", got, want)
+	}
+
+	if _, err := compileJS("template", "{{price | lower}}", load, &jsOptions{filters: filters}); err == nil {
+		t.Error("compileJS did not return an error for an unknown filter")
+	}
+	if _, err := compileJS("template", filtersTemplate, load, &jsOptions{format: "iife", filters: filters}); err == nil {
+		t.Error("compileJS did not return an error for filters in an iife")
+	}
+	if _, err := loadJSFilters("filters", filepath.Join(tempDir, "template.mjs")); err == nil {
+		t.Error("loadJSFilters did not return an error for a package name")
+	}
+}
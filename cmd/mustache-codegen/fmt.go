@@ -0,0 +1,347 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/kagisearch/mustache-codegen/internal/syntax"
+)
+
+// fmtIndent is the indentation that [formatTemplate] adds
+// to standalone tags for each level of nesting.
+const fmtIndent = "  "
+
+// fmtMain runs the fmt command, which formats templates like gofmt.
+func fmtMain(args []string) {
+	fset := flag.FlagSet{Usage: func() {}}
+	list := fset.Bool("l", false, "list files whose formatting differs")
+	write := fset.Bool("w", false, "write the result to the files instead of standard output")
+	diff := fset.Bool("d", false, "print diffs instead of the formatted templates")
+	if err := fset.Parse(args); err != nil {
+		fmt.Fprintf(fset.Output(), "usage: %s fmt [options] [TEMPLATE...]\n\n", programName)
+		fset.PrintDefaults()
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		os.Exit(64) // EX_USAGE
+	}
+
+	if fset.NArg() == 0 {
+		if *write {
+			fmt.Fprintf(os.Stderr, "%s: cannot use -w with standard input\n", programName)
+			os.Exit(64) // EX_USAGE
+		}
+		source, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
+			os.Exit(1)
+		}
+		if err := fmtFile("<standard input>", source, *list, false, *diff); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: <standard input> %v\n", programName, err)
+			os.Exit(1)
+		}
+		return
+	}
+	failed := false
+	for _, fname := range fset.Args() {
+		source, err := os.ReadFile(fname)
+		if err == nil {
+			err = fmtFile(fname, source, *list, *write, *diff)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s %v\n", programName, fname, err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// fmtFile formats the source of the named template
+// and reports or writes the result as requested by the fmt command's flags.
+func fmtFile(fname string, source []byte, list, write, diff bool) error {
+	formatted, err := formatTemplate(string(source))
+	if err != nil {
+		return err
+	}
+	changed := !bytes.Equal(source, formatted)
+	if list && changed {
+		fmt.Println(fname)
+	}
+	if diff && changed {
+		os.Stdout.Write(unifiedDiff(fname+".orig", fname, source, formatted))
+	}
+	if write && changed {
+		info, err := os.Stat(fname)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(fname, formatted, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if !list && !write && !diff {
+		os.Stdout.Write(formatted)
+	}
+	return nil
+}
+
+// formatTemplate returns the template source in canonical form:
+//
+//   - Tags have no whitespace around their names,
+//     filters are separated by " | ",
+//     and triple mustaches are written as {{&name}}.
+//   - Standalone section, comment, pragma, and set delimiter tags
+//     are indented by their nesting.
+//
+// Comments and text are kept as they are.
+// It returns an error if the template does not parse
+// or if formatting would change what the template renders.
+func formatTemplate(source string) ([]byte, error) {
+	opts := &syntax.Options{Filters: true}
+	want, err := syntax.ParseOptions(source, opts)
+	if err != nil {
+		// Names may contain "|" if the template does not use filters.
+		opts = nil
+		want, err = syntax.Parse(source)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Reindenting standalone tags can change the output of blocks
+	// that remove their indentation from their content,
+	// so fall back to only normalizing the tags.
+	for _, reindent := range []bool{true, false} {
+		formatted := formatTags(source, reindent)
+		got, err := syntax.ParseOptions(formatted, opts)
+		if err == nil && slices.EqualFunc(got, want, syntax.TagsEqual) {
+			return []byte(formatted), nil
+		}
+	}
+	return nil, errors.New("formatting would change the template's output")
+}
+
+// formatTags rewrites the tags in source in canonical form.
+// If reindent is true, it also indents standalone tags
+// that do not use their indentation by their nesting.
+func formatTags(source string, reindent bool) string {
+	tags := scanTags(source)
+	sb := new(strings.Builder)
+	startDelim, endDelim := "{{", "}}"
+	var stack []scannedTag
+	prevEnd := 0
+	for i, t := range tags {
+		if t.unclosed {
+			break
+		}
+		// closed is the sigil of the tag that a closing tag closes.
+		var closed byte
+		switch t.sigil {
+		case '#', '^', '<', '$':
+			stack = append(stack, t)
+		case '/':
+			for j := len(stack) - 1; j >= 0; j-- {
+				if stack[j].name == t.name {
+					closed = stack[j].sigil
+					stack = stack[:j]
+					break
+				}
+			}
+		}
+
+		// Only sections, comments, pragmas, and set delimiter tags are reindented:
+		// the indentation of partials, parents, and blocks affects their content.
+		depth := -1
+		switch t.sigil {
+		case '#', '^':
+			// The section is already on the stack.
+			depth = len(stack) - 1
+		case '/':
+			if closed == '#' || closed == '^' {
+				depth = len(stack)
+			}
+		case '!', '%', '=':
+			depth = len(stack)
+		}
+		lineStart := strings.LastIndexByte(source[:t.start], '\n') + 1
+		lineEnd := t.end + indexLineEnd(source[t.end:])
+		standalone := lineStart >= prevEnd &&
+			strings.TrimSpace(source[lineStart:t.start]) == "" &&
+			strings.TrimSpace(source[t.end:lineEnd]) == "" &&
+			(i+1 == len(tags) || tags[i+1].start >= lineEnd) &&
+			!strings.Contains(source[t.start:t.end], "\n")
+		if reindent && standalone && depth >= 0 {
+			sb.WriteString(source[prevEnd:lineStart])
+			sb.WriteString(strings.Repeat(fmtIndent, depth))
+			prevEnd = t.start
+		}
+		sb.WriteString(source[prevEnd:t.start])
+		sb.WriteString(formatTag(t, startDelim, endDelim))
+		prevEnd = t.end
+		if t.sigil == '=' {
+			if delims := strings.Fields(t.text); len(delims) == 2 {
+				startDelim, endDelim = delims[0], delims[1]
+			}
+		}
+	}
+	sb.WriteString(source[prevEnd:])
+	return sb.String()
+}
+
+// formatTag returns a tag in canonical form with the given delimiters.
+func formatTag(t scannedTag, startDelim, endDelim string) string {
+	text := strings.TrimSpace(t.text)
+	switch t.sigil {
+	case 0, '&':
+		if strings.ContainsFunc(text, isFmtSpace) {
+			// Only filter pipelines can contain spaces.
+			parts := strings.Split(text, "|")
+			for i := range parts {
+				parts[i] = strings.TrimSpace(parts[i])
+			}
+			text = strings.Join(parts, " | ")
+		}
+	case '!':
+		// Comments are kept as they are.
+		text = t.text
+	case '=':
+		text = strings.Join(strings.Fields(t.text), " ") + "="
+	case '%':
+		if name, value, ok := strings.Cut(text, "="); ok {
+			text = strings.TrimSpace(name) + "=" + strings.TrimSpace(value)
+		}
+	}
+	sigil := ""
+	if t.sigil != 0 {
+		sigil = string(t.sigil)
+	}
+	return startDelim + sigil + text + endDelim
+}
+
+func isFmtSpace(c rune) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+// indexLineEnd returns the index of the first newline in s,
+// or len(s) if there is none.
+func indexLineEnd(s string) int {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return i
+	}
+	return len(s)
+}
+
+// unifiedDiff returns the differences between the lines of a and b
+// in unified diff format with three lines of context.
+func unifiedDiff(aName, bName string, aData, bData []byte) []byte {
+	a := strings.SplitAfter(string(aData), "\n")
+	b := strings.SplitAfter(string(bData), "\n")
+	if a[len(a)-1] == "" {
+		a = a[:len(a)-1]
+	}
+	if b[len(b)-1] == "" {
+		b = b[:len(b)-1]
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	// ops is the edit script: ' ' for common lines, '-' for deletions, '+' for insertions.
+	type op struct {
+		kind byte
+		line string
+		i, j int
+	}
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, op{' ', a[i], i, j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, op{'-', a[i], i, j})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j], i, j})
+			j++
+		}
+	}
+
+	const context = 3
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "--- %s\n+++ %s\n", aName, bName)
+	for start := 0; start < len(ops); {
+		// Find the next change and the extent of its hunk.
+		first := slices.IndexFunc(ops[start:], func(o op) bool { return o.kind != ' ' })
+		if first < 0 {
+			break
+		}
+		first += start
+		hunkStart := max(first-context, start)
+		hunkEnd := first
+		for k := first; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				hunkEnd = k + 1
+			} else if k-hunkEnd >= 2*context {
+				break
+			}
+		}
+		hunkEnd = min(hunkEnd+context, len(ops))
+
+		oldCount, newCount := 0, 0
+		for _, o := range ops[hunkStart:hunkEnd] {
+			if o.kind != '+' {
+				oldCount++
+			}
+			if o.kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(buf, "@@ -%s +%s @@\n", diffRange(ops[hunkStart].i, oldCount), diffRange(ops[hunkStart].j, newCount))
+		for _, o := range ops[hunkStart:hunkEnd] {
+			buf.WriteByte(o.kind)
+			buf.WriteString(o.line)
+			if !strings.HasSuffix(o.line, "\n") {
+				buf.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		start = hunkEnd
+	}
+	return buf.Bytes()
+}
+
+// diffRange formats the range of a hunk of a unified diff
+// that starts at the zero-based line start.
+func diffRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import "testing"
+
+func TestFormatTemplate(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "Variables",
+			source: "{{ name }} {{{ raw }}} {{& x }} {{ a|b | c }}",
+			want:   "{{name}} {{&raw}} {{&x}} {{a | b | c}}",
+		},
+		{
+			name:   "Comment",
+			source: "{{!  keep  this }}",
+			want:   "{{!  keep  this }}",
+		},
+		{
+			name:   "Sections",
+			source: "{{# items }}\n{{^ empty }}\n{{! note }}\n    {{ name }}\n        {{/ empty }}\n{{/items}}\n",
+			want:   "{{#items}}\n  {{^empty}}\n    {{! note }}\n    {{name}}\n  {{/empty}}\n{{/items}}\n",
+		},
+		{
+			name:   "NotStandalone",
+			source: "{{#a}} {{#b}}x{{/b}}\n{{/a}}\n",
+			want:   "{{#a}} {{#b}}x{{/b}}\n{{/a}}\n",
+		},
+		{
+			name:   "Delimiters",
+			source: "{{= <%  %> =}}<% x %>\n<%={{ }}=%>{{ y }}",
+			want:   "{{=<% %>=}}<%x%>\n<%={{ }}=%>{{y}}",
+		},
+		{
+			name:   "Pragma",
+			source: "{{% GO_TYPE=*Page }}\n",
+			want:   "{{%GO_TYPE=*Page}}\n",
+		},
+		{
+			// Blocks remove the indentation of their first line from their content,
+			// so only the tags are normalized.
+			name:   "Block",
+			source: "{{<base}}\n{{$body}}\n{{# x }}\ny\n{{/x}}\n{{/body}}\n{{/base}}\n",
+			want:   "{{<base}}\n{{$body}}\n{{#x}}\ny\n{{/x}}\n{{/body}}\n{{/base}}\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := formatTemplate(test.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.want {
+				t.Errorf("formatTemplate(%q) = %q; want %q", test.source, got, test.want)
+			}
+			again, err := formatTemplate(string(got))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(again) != string(got) {
+				t.Errorf("formatting is not idempotent: %q became %q", got, again)
+			}
+		})
+	}
+
+	if _, err := formatTemplate("{{#a}}"); err == nil {
+		t.Error("formatTemplate did not return an error for an unclosed section")
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	b := "1\nTwo\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13"
+	const want = "--- a\n+++ b\n" +
+		"@@ -1,5 +1,5 @@\n 1\n-2\n+Two\n 3\n 4\n 5\n" +
+		"@@ -10,3 +10,4 @@\n 10\n 11\n 12\n+13\n\\ No newline at end of file\n"
+	if got := string(unifiedDiff("a", "b", []byte(a), []byte(b))); got != want {
+		t.Errorf("unifiedDiff(...) =\n%s\nwant:\n%s", got, want)
+	}
+}
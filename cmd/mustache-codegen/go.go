@@ -6,48 +6,302 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"go/build/constraint"
 	gofmt "go/format"
+	"go/token"
+	"go/types"
 	"slices"
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
+
+	"github.com/kagisearch/mustache-codegen/go/mustache"
+	"github.com/kagisearch/mustache-codegen/internal/syntax"
+)
+
+const (
+	supportImportPath = "github.com/kagisearch/mustache-codegen/go/mustache"
+	interpImportPath  = "github.com/kagisearch/mustache-codegen/go/mustache/interp"
 )
 
-const supportImportPath = "github.com/kagisearch/mustache-codegen/go/mustache"
+// goOptions is the set of options for [compileGo].
+type goOptions struct {
+	// packageName is the name of the generated file's package.
+	packageName string
+	// funcName is the name of the generated function.
+	// If empty, the name is derived from the template name.
+	funcName string
+	// unexported is whether a function name derived from the template name
+	// should be unexported.
+	unexported bool
+	// receiver is the optional receiver type of the generated function
+	// (e.g. "T" or "*T").
+	// If empty, the generated function is not a method.
+	receiver string
+	// buildTags is an optional build constraint expression for the generated file.
+	buildTags string
+	// interpret is whether to generate a function that renders the template file
+	// with the interpreter at run time instead of compiling the template.
+	interpret bool
+	// templatePath is the slash-separated path to the template file
+	// relative to the directory of the generated file.
+	// It is required if interpret is true.
+	templatePath string
+	// escaper is the set of characters escaped in variables.
+	escaper mustache.Escaper
+	// minifyHTML is whether to minify the HTML in the template's text
+	// with [syntax.MinifyHTML].
+	minifyHTML bool
+	// frontMatter is whether the template may start with front matter,
+	// which is declared as a variable instead of rendered.
+	frontMatter bool
+	// translator is whether the generated function takes a [mustache.Translator]
+	// that translates the messages in {{#_i18n}} sections.
+	translator bool
+	// translations is the list of catalogs to translate the template with
+	// at compile time.
+	// If it is not empty, a function is generated for each translation
+	// with the locale appended to its name (e.g. FooBar_de).
+	translations []*translation
+	// filters is the package whose functions variables can be filtered with.
+	// If it is nil, filter pipelines are not recognized.
+	filters *filterPackage
+	// dataType is the type of the generated function's data parameter
+	// as accepted by [goDataType].
+	// If empty, the type is any.
+	dataType string
+	// strict is whether variables that cannot be found panic.
+	strict bool
+}
+
+// goEscapers maps each escaper to the expression that refers to it
+// in generated code.
+var goEscapers = map[mustache.Escaper]string{
+	mustache.EscapeMinimal: "m.EscapeMinimal",
+	mustache.EscapeQuotes:  "m.EscapeQuotes",
+	mustache.EscapeNone:    "m.EscapeNone",
+}
 
-func compileGo(packageName string, templateName string, source string, load func(name string) (string, error)) ([]byte, error) {
-	tags, err := parse(string(source))
+func compileGo(templateName string, source string, load func(name string) (string, error), opts *goOptions) ([]byte, error) {
+	tags, err := syntax.ParseOptions(string(source), goParseOptions(opts))
 	if err != nil {
 		return nil, err
 	}
+	// The interpreter applies the ESCAPE and STRICT pragmas itself
+	// when it reads the template, so they can change without regenerating code.
+	flagOpts := opts
+	opts, err = goPragmaOptions(tags, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	funcName := opts.funcName
+	if funcName == "" {
+		funcName = goFuncName(templateName, !opts.unexported)
+	} else if !token.IsIdentifier(funcName) || funcName == "_" {
+		return nil, fmt.Errorf("invalid Go function name %q", funcName)
+	} else if opts.receiver == "" && goReservedName(funcName) {
+		return nil, fmt.Errorf("invalid Go function name %q: it is predeclared or imported by the generated code", funcName)
+	}
+	// Helper functions are always unexported package-level functions,
+	// so include the receiver type in their names to avoid collisions
+	// between methods of the same name.
+	helperPrefix := "_" + funcName
+	var receiverDecl string
+	if opts.receiver != "" {
+		typeName := strings.TrimPrefix(opts.receiver, "*")
+		if !token.IsIdentifier(typeName) || typeName == "_" {
+			return nil, fmt.Errorf("invalid Go receiver type %q", opts.receiver)
+		}
+		helperPrefix = "_" + typeName + helperPrefix
+		receiverDecl = "(" + opts.receiver + ") "
+	}
+	if opts.buildTags != "" {
+		if _, err := constraint.Parse("//go:build " + opts.buildTags); err != nil {
+			return nil, fmt.Errorf("invalid Go build tags: %v", err)
+		}
+	}
+	escaper := goEscapers[opts.escaper]
+	if escaper == "" {
+		return nil, fmt.Errorf("unknown escaper %d", opts.escaper)
+	}
+	if opts.interpret && opts.templatePath == "" {
+		return nil, fmt.Errorf("interpreting a template requires a template file")
+	}
+	dataImport, _, err := goDataType(opts.dataType, opts.packageName)
+	if err != nil {
+		return nil, err
+	}
+	// The size hint constant is named after the function,
+	// prefixed with the receiver type's name for methods.
+	sizeHintPrefix := funcName
+	if opts.receiver != "" {
+		sizeHintPrefix = strings.TrimPrefix(opts.receiver, "*") + sizeHintPrefix
+	}
+	// So is the variable that holds the front matter.
+	meta := new(bytes.Buffer)
+	if fields, ok, err := templateMeta(tags); err != nil {
+		return nil, err
+	} else if ok {
+		if err := writeGoMeta(meta, sizeHintPrefix+"Meta", receiverDecl+funcName, fields); err != nil {
+			return nil, err
+		}
+	}
+	if opts.interpret {
+		if len(opts.translations) > 0 {
+			return nil, fmt.Errorf("interpreted templates cannot be translated at compile time")
+		}
+		if opts.filters != nil {
+			return nil, fmt.Errorf("interpreted templates cannot use filters")
+		}
+		// We've checked that the template parses,
+		// but its source will be read again at run time.
+		t, err := loadGoTemplate(templateName, tags, load, helperPrefix, opts, nil)
+		if err != nil {
+			return nil, err
+		}
+		interpOpts := *opts
+		interpOpts.escaper = flagOpts.escaper
+		interpOpts.strict = flagOpts.strict
+		return compileInterpretedGo(funcName, receiverDecl, dataImport, sizeHintPrefix+"SizeHint", t.sizeHint, meta.Bytes(), &interpOpts)
+	}
 
-	var partials [][]tag
-	partialFuncNames := make(map[string]string)
+	// Generate a function for each translation,
+	// or a single function if the template is not translated at compile time.
+	translations := opts.translations
+	if len(translations) == 0 {
+		translations = []*translation{nil}
+	}
+	templates := make([]*goTemplate, len(translations))
+	suffixes := make([]string, len(translations))
+	usesFilters := false
+	for i, tr := range translations {
+		if tr != nil {
+			suffixes[i], err = localeSuffix(tr.locale)
+			if err != nil {
+				return nil, err
+			}
+			if slices.Contains(suffixes[:i], suffixes[i]) {
+				return nil, fmt.Errorf("locale %s has the same function name suffix as another locale", tr.locale)
+			}
+		}
+		templates[i], err = loadGoTemplate(templateName, tags, load, helperPrefix+suffixes[i], opts, tr)
+		if err != nil {
+			return nil, err
+		}
+		usesFilters = usesFilters || templates[i].usesFilters
+	}
 
-	var gatherPartials func(tags []tag) error
-	gatherPartials = func(tags []tag) error {
-		for t := range walkTags(tags) {
-			if t.tt == partial || t.tt == parent {
-				if partialFuncNames[t.s] != "" {
+	buf := new(bytes.Buffer)
+	writeGoHeader(buf, opts)
+	fmt.Fprintln(buf, "import (")
+	fmt.Fprintln(buf, "\t\"bytes\"")
+	fmt.Fprintln(buf)
+	fmt.Fprintf(buf, "\tm %q\n", supportImportPath)
+	if usesFilters {
+		fmt.Fprintf(buf, "\tfilters %q\n", opts.filters.path)
+	}
+	writeGoDataImport(buf, dataImport)
+	fmt.Fprintln(buf, ")")
+
+	fmt.Fprintln(buf, "// Ignore unused imports.")
+	fmt.Fprintln(buf, "var _ = m.Lookup")
+	buf.Write(meta.Bytes())
+
+	for i, t := range templates {
+		suffix := suffixes[i]
+		if err := t.write(buf, receiverDecl, funcName+suffix, sizeHintPrefix+suffix+"SizeHint", escaper, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	formatted, err := gofmt.Source(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return formatted, nil
+}
+
+// goTemplate is a template and its partials, ready to be compiled to Go.
+type goTemplate struct {
+	tags []syntax.Tag
+	// partials is the list of distinct partials used by the template.
+	partials [][]syntax.Tag
+	// partialFuncNames maps partial names to their generated function names.
+	partialFuncNames map[string]string
+	// helperPrefix is the prefix of the names of the generated helper functions.
+	helperPrefix string
+	// sizeHint is the template's static size as returned by [staticSize].
+	sizeHint int
+	// usesFilters is whether the template or its partials apply filters to variables.
+	usesFilters bool
+}
+
+// loadGoTemplate loads the partials used by the template with the given tags.
+// If tr is not nil, the messages in the template and its partials are translated.
+func loadGoTemplate(templateName string, tags []syntax.Tag, load func(name string) (string, error), helperPrefix string, opts *goOptions, tr *translation) (*goTemplate, error) {
+	prepare := func(partial string, tags []syntax.Tag) ([]syntax.Tag, error) {
+		if tr != nil {
+			var err error
+			tags, err = tr.translate(templateName, partial, tags)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if opts.minifyHTML {
+			if partial == "" {
+				tags = syntax.MinifyHTML(tags)
+			} else {
+				tags = syntax.MinifyHTMLPartial(tags)
+			}
+		}
+		return tags, nil
+	}
+	tags, err := prepare("", tags)
+	if err != nil {
+		return nil, err
+	}
+	t := &goTemplate{
+		tags:             tags,
+		partialFuncNames: make(map[string]string),
+		helperPrefix:     helperPrefix,
+	}
+	partialsByName := make(map[string][]syntax.Tag)
+
+	var gatherPartials func(tags []syntax.Tag) error
+	gatherPartials = func(tags []syntax.Tag) error {
+		for tag := range syntax.WalkTags(tags) {
+			if tag.Type == syntax.Partial || tag.Type == syntax.Parent {
+				if t.partialFuncNames[tag.S] != "" {
 					continue
 				}
-				source, err := load(t.s)
+				source, err := load(tag.S)
 				if err != nil {
 					return err
 				}
-				partialTags, err := parse(source)
+				partialTags, err := syntax.ParseOptions(source, goParseOptions(opts))
+				if err == nil {
+					// Pragmas only apply to the template itself,
+					// but are checked in partials too.
+					_, err = syntax.Pragmas(partialTags)
+				}
+				if err == nil {
+					partialTags, err = prepare(tag.S, partialTags)
+				}
 				if err != nil {
-					return fmt.Errorf("partial %s: %v", t.s, err)
+					return fmt.Errorf("partial %s: %v", tag.S, err)
 				}
 
-				i := slices.IndexFunc(partials, func(p []tag) bool {
-					return slices.EqualFunc(partialTags, p, tagsEqual)
+				i := slices.IndexFunc(t.partials, func(p []syntax.Tag) bool {
+					return slices.EqualFunc(partialTags, p, syntax.TagsEqual)
 				})
 				if i == -1 {
-					partials = append(partials, partialTags)
-					i = len(partials) - 1
+					t.partials = append(t.partials, partialTags)
+					i = len(t.partials) - 1
 				}
-				partialFuncNames[t.s] = fmt.Sprintf("_%s_p%d", templateName, i)
+				t.partialFuncNames[tag.S] = fmt.Sprintf("%s_p%d", helperPrefix, i)
+				partialsByName[tag.S] = partialTags
 				if err := gatherPartials(partialTags); err != nil {
 					return err
 				}
@@ -58,41 +312,140 @@ func compileGo(packageName string, templateName string, source string, load func
 	if err := gatherPartials(tags); err != nil {
 		return nil, err
 	}
+	t.sizeHint = staticSize(tags, partialsByName, make(map[string]bool))
+	for _, tags := range append([][]syntax.Tag{tags}, t.partials...) {
+		for tag := range syntax.WalkTags(tags) {
+			t.usesFilters = t.usesFilters || len(tag.Filters) > 0
+		}
+	}
+	return t, nil
+}
 
-	buf := new(bytes.Buffer)
+// goParseOptions returns the syntax extensions enabled by opts.
+func goParseOptions(opts *goOptions) *syntax.Options {
+	return &syntax.Options{
+		Filters:     opts.filters != nil,
+		I18n:        opts.translator || len(opts.translations) > 0,
+		FrontMatter: opts.frontMatter,
+	}
+}
+
+// write writes the template's size hint, function, partials, and helpers to buf.
+func (t *goTemplate) write(buf *bytes.Buffer, receiverDecl, funcName, sizeHintName, escaper string, opts *goOptions) error {
+	g := &goGenerator{
+		helperPrefix:     t.helperPrefix,
+		partialFuncNames: t.partialFuncNames,
+		escaper:          escaper,
+		filters:          opts.filters,
+		strict:           opts.strict,
+		helpers:          new(bytes.Buffer),
+	}
+	writeGoSizeHint(buf, funcName, receiverDecl, sizeHintName, t.sizeHint)
+
+	fmt.Fprintf(buf, "\nfunc %s%s(%s) {\n", receiverDecl, funcName, goParams(opts))
+	if t.sizeHint > 0 {
+		fmt.Fprintf(buf, "\tbuf.Grow(%s)\n", sizeHintName)
+	}
+	fmt.Fprintln(buf, "\tstack := m.GetStack(data)")
+	fmt.Fprintln(buf, "\tdefer m.PutStack(stack)")
+	if opts.translator {
+		fmt.Fprintln(buf, "\tstack.SetTranslator(tr)")
+	}
+	if err := compileTagListGo(buf, t.tags, g, false, false); err != nil {
+		return err
+	}
+	fmt.Fprintln(buf, "}")
+
+	for i, partialTags := range t.partials {
+		fmt.Fprintf(buf, "\nfunc %s_p%d(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {\n", t.helperPrefix, i)
+		if err := compileTagListGo(buf, partialTags, g, true, true); err != nil {
+			return err
+		}
+		fmt.Fprintln(buf, "}")
+	}
+	g.helpers.WriteTo(buf)
+	return nil
+}
+
+// goGenerator holds the state shared by the functions
+// that generate the Go code for a template.
+type goGenerator struct {
+	// helperPrefix is the prefix of the names of the generated helper functions.
+	helperPrefix string
+	// partialFuncNames maps partial names to their generated function names.
+	partialFuncNames map[string]string
+	// escaper is the expression for the [mustache.Escaper] used for variables.
+	escaper string
+	// filters is the package of filter functions, if any.
+	filters *filterPackage
+	// strict is whether variables that cannot be found panic.
+	strict bool
+
+	// helpers accumulates the source of helper functions for parent tags
+	// to be written after the template's functions.
+	helpers *bytes.Buffer
+	// nblocks is the number of block argument functions generated so far.
+	nblocks int
+	// ntables is the number of block table functions generated so far.
+	ntables int
+}
+
+// writeGoHeader writes the beginning of a generated Go file
+// up to and including the package clause.
+func writeGoHeader(buf *bytes.Buffer, opts *goOptions) {
 	fmt.Fprintln(buf, "// Code generated by mustache-codegen. DO NOT EDIT.")
 	fmt.Fprintln(buf)
-	fmt.Fprintf(buf, "package %s\n", packageName)
+	if opts.buildTags != "" {
+		fmt.Fprintf(buf, "//go:build %s\n", opts.buildTags)
+		fmt.Fprintln(buf)
+	}
+	fmt.Fprintf(buf, "package %s\n", opts.packageName)
+}
+
+// compileInterpretedGo generates a Go function that renders the template file
+// using the interpreter, so that changes to the template take effect without regenerating code.
+// The template file is located relative to the generated source file
+// as recorded by the compiler, so the function only works in the source tree it was built in:
+// it panics if the binary was built with -trimpath or is run elsewhere.
+func compileInterpretedGo(funcName, receiverDecl, dataImport, sizeHintName string, sizeHint int, meta []byte, opts *goOptions) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	writeGoHeader(buf, opts)
 	fmt.Fprintln(buf, "import (")
 	fmt.Fprintln(buf, "\t\"bytes\"")
-	fmt.Fprintln(buf, "\t\"html\"")
-	fmt.Fprintln(buf, "\t\"reflect\"")
+	fmt.Fprintln(buf, "\t\"path/filepath\"")
+	fmt.Fprintln(buf, "\t\"runtime\"")
 	fmt.Fprintln(buf)
 	fmt.Fprintf(buf, "\tm %q\n", supportImportPath)
+	fmt.Fprintf(buf, "\t%q\n", interpImportPath)
+	writeGoDataImport(buf, dataImport)
 	fmt.Fprintln(buf, ")")
+	// Declare the size hint so that code that uses it
+	// builds with either variant of the function.
+	writeGoSizeHint(buf, funcName, receiverDecl, sizeHintName, sizeHint)
+	buf.Write(meta)
 
-	fmt.Fprintln(buf, "// Ignore unused imports.")
-	fmt.Fprintln(buf, "var (")
-	fmt.Fprintln(buf, "\t_ = html.EscapeString")
-	fmt.Fprintln(buf, "\t_ = reflect.ValueOf")
-	fmt.Fprintln(buf, "\t_ = m.Lookup")
-	fmt.Fprintln(buf, ")")
-
-	fmt.Fprintf(buf, "\nfunc %s(buf *bytes.Buffer, data any) {\n", lowerSnakeToUpperCamel(templateName))
-	fmt.Fprintln(buf, "\tstack := []reflect.Value{reflect.ValueOf(data)}")
-	fmt.Fprintln(buf, "\t_ = stack")
-	if err := compileTagListGo(buf, tags, partialFuncNames, false, false); err != nil {
-		return nil, err
+	fmt.Fprintf(buf, "\nfunc %s%s(%s) {\n", receiverDecl, funcName, goParams(opts))
+	fmt.Fprintln(buf, "\t_, file, _, _ := runtime.Caller(0)")
+	fmt.Fprintf(buf, "\tpath := filepath.Join(filepath.Dir(file), %q)\n", opts.templatePath)
+	fmt.Fprintln(buf, "\tif err := interp.RenderFileOptions(buf, path, data, &interp.RenderOptions{")
+	fmt.Fprintf(buf, "\t\tEscaper: %s,\n", goEscapers[opts.escaper])
+	if opts.strict {
+		fmt.Fprintln(buf, "\t\tStrict: true,")
 	}
-	fmt.Fprintln(buf, "}")
-
-	for i, partialTags := range partials {
-		fmt.Fprintf(buf, "\nfunc _%s_p%d(buf *bytes.Buffer, indent string, stack []reflect.Value, blocks map[string]func(*bytes.Buffer, string, []reflect.Value)) {\n", templateName, i)
-		if err := compileTagListGo(buf, partialTags, partialFuncNames, true, true); err != nil {
-			return nil, err
-		}
-		fmt.Fprintln(buf, "}")
+	if opts.translator {
+		fmt.Fprintln(buf, "\t\tI18n: true,")
+		fmt.Fprintln(buf, "\t\tTranslator: tr,")
+	}
+	if opts.minifyHTML {
+		fmt.Fprintln(buf, "\t\tMinifyHTML: true,")
 	}
+	if opts.frontMatter {
+		fmt.Fprintln(buf, "\t\tFrontMatter: true,")
+	}
+	fmt.Fprintln(buf, "\t}); err != nil {")
+	fmt.Fprintln(buf, "\t\tpanic(err)")
+	fmt.Fprintln(buf, "\t}")
+	fmt.Fprintln(buf, "}")
 
 	formatted, err := gofmt.Source(buf.Bytes())
 	if err != nil {
@@ -101,105 +454,286 @@ func compileGo(packageName string, templateName string, source string, load func
 	return formatted, nil
 }
 
-func compileTagListGo(buf *bytes.Buffer, tags []tag, partialFuncNames map[string]string, blocks, indent bool) error {
+// goParams returns the parameter list of the generated function.
+func goParams(opts *goOptions) string {
+	dataType := "any"
+	if opts.dataType != "" {
+		// compileGo has checked that the type is valid.
+		_, dataType, _ = goDataType(opts.dataType, opts.packageName)
+	}
+	if opts.translator {
+		return "buf *bytes.Buffer, data " + dataType + ", tr m.Translator"
+	}
+	return "buf *bytes.Buffer, data " + dataType
+}
+
+// writeGoDataImport writes the import declaration of the package
+// that declares the type of the generated function's data parameter, if any.
+func writeGoDataImport(buf *bytes.Buffer, importPath string) {
+	if importPath == "" || importPath == "bytes" {
+		return
+	}
+	_, typeExpr, _ := goDataType(importPath+".T", "")
+	fmt.Fprintf(buf, "\t%s %q\n", strings.TrimSuffix(typeExpr, ".T"), importPath)
+}
+
+// writeGoSizeHint writes the declaration of the constant
+// that holds the template's static size.
+func writeGoSizeHint(buf *bytes.Buffer, funcName, receiverDecl, sizeHintName string, sizeHint int) {
+	fmt.Fprintf(buf, "\n// %s is the number of bytes of text that %s%s always writes.\n", sizeHintName, receiverDecl, funcName)
+	fmt.Fprintln(buf, "// It can be used to size buffers for the output.")
+	fmt.Fprintf(buf, "const %s = %d\n", sizeHintName, sizeHint)
+}
+
+// staticSize returns the number of bytes of literal text
+// that rendering tags always writes:
+// the text outside of sections and blocks, including the text of partials.
+// partials maps partial names to their tags,
+// and visiting is the set of partials being measured,
+// which contribute nothing if they are included recursively.
+func staticSize(tags []syntax.Tag, partials map[string][]syntax.Tag, visiting map[string]bool) int {
+	n := 0
+	for _, t := range tags {
+		switch t.Type {
+		case syntax.Literal:
+			n += len(t.S)
+		case syntax.Partial, syntax.Parent:
+			if visiting[t.S] {
+				continue
+			}
+			visiting[t.S] = true
+			n += staticSize(partials[t.S], partials, visiting)
+			delete(visiting, t.S)
+		}
+	}
+	return n
+}
+
+func compileTagListGo(buf *bytes.Buffer, tags []syntax.Tag, g *goGenerator, blocks, indent bool) error {
 	for i := 0; i < len(tags); i++ {
 		t := tags[i]
-		if !indent && t.tt == literal {
+		if !indent && t.Type == syntax.Literal {
 			var n int
-			t, n = condenseLiteralsWithoutIndentation(tags[i:])
+			t, n = syntax.CondenseLiteralsWithoutIndentation(tags[i:])
 			i += n - 1
 		}
-		if err := compileTagGo(buf, t, partialFuncNames, blocks, indent); err != nil {
+		if err := compileTagGo(buf, t, g, blocks, indent); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func compileTagGo(buf *bytes.Buffer, t tag, partialFuncNames map[string]string, blocks, indent bool) error {
-	switch t.tt {
-	case literal:
-		fmt.Fprintf(buf, "\tbuf.WriteString(%q)\n", t.s)
-	case indentPoint:
+func compileTagGo(buf *bytes.Buffer, t syntax.Tag, g *goGenerator, blocks, indent bool) error {
+	switch t.Type {
+	case syntax.Literal:
+		fmt.Fprintf(buf, "\tbuf.WriteString(%q)\n", t.S)
+	case syntax.IndentPoint:
 		if indent {
 			fmt.Fprintln(buf, "\tbuf.WriteString(indent)")
 		}
-	case variable:
-		fmt.Fprintf(buf, "\tbuf.WriteString(html.EscapeString(m.ToString(m.Lookup(stack, %q))))\n", t.s)
-	case rawVariable:
-		fmt.Fprintf(buf, "\tbuf.WriteString(m.ToString(m.Lookup(stack, %q)))\n", t.s)
-	case section:
-		fmt.Fprintf(buf, "\tfor e := range m.ForEach(m.Lookup(stack, %q)) {\n", t.s)
-		fmt.Fprintln(buf, "\t\tstack = append(stack, e)")
-		if err := compileTagListGo(buf, t.body, partialFuncNames, blocks, indent); err != nil {
+	case syntax.Variable:
+		value, err := goValue(t, g)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "\t%s.Escape(buf, m.ToString(%s))\n", g.escaper, value)
+	case syntax.RawVariable:
+		value, err := goValue(t, g)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "\tbuf.WriteString(m.ToString(%s))\n", value)
+	case syntax.Pragma:
+		// Pragmas have been applied to the compile options.
+	case syntax.FrontMatter:
+		// Front matter is written as metadata, not rendered.
+	case syntax.Translation:
+		fmt.Fprintf(buf, "\tstack.Translate(buf, %s, %q)\n", g.escaper, t.S)
+	case syntax.Section:
+		fmt.Fprintf(buf, "\tfor it := stack.Iterate(%s); it.Next(); {\n", goLookup(t.S))
+		fmt.Fprintln(buf, "\t\tstack.Push(it.Value())")
+		if err := compileTagListGo(buf, t.Body, g, blocks, indent); err != nil {
 			return err
 		}
-		fmt.Fprintln(buf, "\t\tclear(stack[len(stack)-1:])")
-		fmt.Fprintln(buf, "\t\tstack = stack[:len(stack)-1]")
+		fmt.Fprintln(buf, "\t\tstack.Pop()")
 		fmt.Fprintln(buf, "\t}")
-	case invertedSection:
-		fmt.Fprintf(buf, "\tif m.IsFalsyOrEmptyList(m.Lookup(stack, %q)) {\n", t.s)
-		if err := compileTagListGo(buf, t.body, partialFuncNames, blocks, indent); err != nil {
+	case syntax.InvertedSection:
+		fmt.Fprintf(buf, "\tif m.IsFalsyOrEmptyList(%s) {\n", goLookup(t.S))
+		if err := compileTagListGo(buf, t.Body, g, blocks, indent); err != nil {
 			return err
 		}
 		fmt.Fprintln(buf, "\t}")
-	case partial:
-		fmt.Fprintf(buf, "\t%s(buf, %s, stack, nil)\n", partialFuncNames[t.s], goIncreaseIndent(indent, t.indent))
-	case block:
+	case syntax.Partial:
+		fmt.Fprintf(buf, "\t%s(buf, %s, stack, m.Blocks{})\n", g.partialFuncNames[t.S], goIncreaseIndent(indent, t.Indent))
+	case syntax.Block:
 		if blocks {
-			fmt.Fprintf(buf, "\tif b, ok := blocks[%q]; ok {\n", t.s)
-			fmt.Fprintf(buf, "\t\tb(buf, %s, stack)\n", goIncreaseIndent(t.indentArgument && indent, t.indent))
+			fmt.Fprintf(buf, "\tif b, env := stack.Block(blocks, %q); b != nil {\n", t.S)
+			fmt.Fprintf(buf, "\t\tb(buf, %s, stack, env)\n", goIncreaseIndent(t.IndentArgument && indent, t.Indent))
 			fmt.Fprintln(buf, "\t} else {")
 		}
-		if err := compileTagListGo(buf, t.body, partialFuncNames, blocks, indent); err != nil {
+		if err := compileTagListGo(buf, t.Body, g, blocks, indent); err != nil {
 			return err
 		}
 		if blocks {
 			fmt.Fprintln(buf, "\t}")
 		}
-	case parent:
-		fmt.Fprintln(buf, "\t{")
-		fmt.Fprintln(buf, "\t\tpartialBlocks := make(map[string]func(*bytes.Buffer, string, []reflect.Value))")
-		fmt.Fprintln(buf, "\t\t_ = partialBlocks")
-		for _, blockTag := range t.body {
-			if blockTag.tt != block {
-				continue
-			}
-			fmt.Fprintf(buf, "\t\tpartialBlocks[%q] = func(buf *bytes.Buffer, indent string, stack []reflect.Value) {\n", blockTag.s)
-			if err := compileTagListGo(buf, blockTag.body, partialFuncNames, blocks, true); err != nil {
-				return err
-			}
-			fmt.Fprintln(buf, "\t\t}")
+	case syntax.Parent:
+		tableName, err := compileBlockTableGo(t, g)
+		if err != nil {
+			return err
 		}
+		outerBlocks := "m.Blocks{}"
 		if blocks {
-			fmt.Fprintln(buf, "\t\tfor k, v := range blocks {")
-			fmt.Fprintln(buf, "\t\t\tpartialBlocks[k] = v")
-			fmt.Fprintln(buf, "\t\t}")
+			outerBlocks = "blocks"
 		}
-		fmt.Fprintf(buf, "\t\t%s(buf, %s, stack, partialBlocks)\n", partialFuncNames[t.s], goIncreaseIndent(indent, t.indent))
-		fmt.Fprintln(buf, "\t}")
+		fmt.Fprintf(buf, "\t%s(buf, %s, stack, stack.PushBlocks(%s, %s))\n",
+			g.partialFuncNames[t.S], goIncreaseIndent(indent, t.Indent), outerBlocks, tableName)
+		fmt.Fprintln(buf, "\tstack.PopBlocks()")
 	default:
-		return fmt.Errorf("unhandled tag %d", t.tt)
+		return fmt.Errorf("unhandled tag %d", t.Type)
 	}
 	return nil
 }
 
-func lowerSnakeToUpperCamel(s string) string {
+// compileBlockTableGo generates helper functions for the block arguments of a parent tag
+// along with a [mustache.BlockTable] function that returns them.
+// It returns the name of the block table function.
+func compileBlockTableGo(t syntax.Tag, g *goGenerator) (string, error) {
+	tableName := fmt.Sprintf("%s_t%d", g.helperPrefix, g.ntables)
+	g.ntables++
+
+	// Later block arguments with the same name take precedence.
+	var names []string
+	blockFuncNames := make(map[string]string)
+	for _, blockTag := range t.Body {
+		if blockTag.Type != syntax.Block {
+			continue
+		}
+		funcName := fmt.Sprintf("%s_b%d", g.helperPrefix, g.nblocks)
+		g.nblocks++
+		if _, seen := blockFuncNames[blockTag.S]; !seen {
+			names = append(names, blockTag.S)
+		}
+		blockFuncNames[blockTag.S] = funcName
+
+		// Block arguments are compiled in a separate buffer
+		// because they may contain parent tags that generate helpers themselves.
+		blockBuf := new(bytes.Buffer)
+		fmt.Fprintf(blockBuf, "\nfunc %s(buf *bytes.Buffer, indent string, stack *m.Stack, blocks m.Blocks) {\n", funcName)
+		if err := compileTagListGo(blockBuf, blockTag.Body, g, true, true); err != nil {
+			return "", err
+		}
+		fmt.Fprintln(blockBuf, "}")
+		blockBuf.WriteTo(g.helpers)
+	}
+
+	fmt.Fprintf(g.helpers, "\nfunc %s(name string) m.BlockFunc {\n", tableName)
+	fmt.Fprintln(g.helpers, "\tswitch name {")
+	for _, name := range names {
+		fmt.Fprintf(g.helpers, "\tcase %q:\n", name)
+		fmt.Fprintf(g.helpers, "\t\treturn %s\n", blockFuncNames[name])
+	}
+	fmt.Fprintln(g.helpers, "\t}")
+	fmt.Fprintln(g.helpers, "\treturn nil")
+	fmt.Fprintln(g.helpers, "}")
+	return tableName, nil
+}
+
+// goValue returns the expression for the value of a variable tag
+// with its filters applied.
+func goValue(t syntax.Tag, g *goGenerator) (string, error) {
+	value := goLookup(t.S)
+	if g.strict && t.S != "." {
+		value = fmt.Sprintf("m.Strict(%s, %q)", value, t.S)
+	}
+	if len(t.Filters) == 0 {
+		return value, nil
+	}
+	value = "m.FilterArg(" + value + ")"
+	for _, filter := range t.Filters {
+		funcName, err := g.filters.filterFuncName(filter, false)
+		if err != nil {
+			return "", fmt.Errorf("%d: %v", t.Line, err)
+		}
+		value = "filters." + funcName + "(" + value + ")"
+	}
+	return "m.FilterResult(" + value + ")", nil
+}
+
+// goLookup returns a Go expression that looks up the given name
+// in the context stack variable.
+// The name is split at its dots at compile time.
+func goLookup(name string) string {
+	if name == "." {
+		return "stack.Top()"
+	}
 	sb := new(strings.Builder)
-	capitalize := true
-	for _, c := range s {
-		if capitalize {
-			sb.WriteRune(unicode.ToUpper(c))
-			capitalize = false
-		} else if c == '_' {
-			capitalize = true
-		} else {
-			sb.WriteRune(c)
+	sb.WriteString("stack.Lookup(")
+	for i, part := range strings.Split(name, ".") {
+		if i > 0 {
+			sb.WriteString(", ")
 		}
+		sb.WriteString(strconv.Quote(part))
 	}
+	sb.WriteString(")")
 	return sb.String()
 }
 
+// goImportNames is the set of package names that generated files import.
+var goImportNames = map[string]bool{
+	"bytes":    true,
+	"m":        true,
+	"filters":  true,
+	"interp":   true,
+	"filepath": true,
+	"runtime":  true,
+}
+
+// goReservedName reports whether a package-level function with the given name
+// would not compile or would shadow an identifier used by the generated code.
+func goReservedName(name string) bool {
+	return token.IsKeyword(name) || types.Universe.Lookup(name) != nil || goImportNames[name]
+}
+
+// goFuncName derives a Go function name from a template name
+// as described by [camelName].
+// Unexported names are suffixed with "_" if they would be a Go keyword,
+// a predeclared identifier, or the name of a package imported by the generated code.
+func goFuncName(templateName string, exported bool) string {
+	name := camelName(templateName, exported)
+	if !exported && goReservedName(name) {
+		name += "_"
+	}
+	return name
+}
+
+// camelName derives an identifier from a template name.
+// Runs of characters that are not letters or digits (like "_", "-", or ".")
+// are treated as word separators and the words are joined in camel case.
+// The result is prefixed with "Template" if it would not otherwise
+// start with a letter or be exported as requested.
+func camelName(templateName string, exported bool) string {
+	words := strings.FieldsFunc(templateName, func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	})
+	sb := new(strings.Builder)
+	for _, w := range words {
+		first, size := utf8.DecodeRuneInString(w)
+		sb.WriteRune(unicode.ToUpper(first))
+		sb.WriteString(w[size:])
+	}
+	name := sb.String()
+	if first, _ := utf8.DecodeRuneInString(name); !unicode.IsUpper(first) {
+		name = "Template" + name
+	}
+	if !exported {
+		first, size := utf8.DecodeRuneInString(name)
+		name = string(unicode.ToLower(first)) + name[size:]
+	}
+	return name
+}
+
 func goIndentArg(indent bool) string {
 	if indent {
 		return "indent"
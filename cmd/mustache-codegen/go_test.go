@@ -5,13 +5,22 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	gofmt "go/format"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/kagisearch/mustache-codegen/go/mustache"
+	"github.com/kagisearch/mustache-codegen/go/mustache/interp"
 )
 
+var updateSpecBench = flag.Bool("update-spec-bench", false, "regenerate the spec benchmark package in bench/spec")
+
 func TestCompileGo(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping for -short")
@@ -21,16 +30,6 @@ func TestCompileGo(t *testing.T) {
 		t.Skip("Cannot find go(?!):", err)
 	}
 
-	overrides := map[string]string{
-		// Go's html.EscapeString function uses &#34; instead of &quot; because it's shorter.
-		// (See https://cs.opensource.google/go/go/+/refs/tags/go1.23.4:src/html/escape.go;l=171)
-		// Ideally our tests would normalize the HTML while comparing,
-		// but for now, we override the golden value to use the entity escape.
-		"Interpolation/HTMLEscaping":                  "These characters should be HTML escaped: &amp; &#34; &lt; &gt;\n",
-		"Interpolation/ImplicitIteratorsHTMLEscaping": "These characters should be HTML escaped: &amp; &#34; &lt; &gt;\n",
-		"Sections/ImplicitIteratorHTMLEscaping":       "\"(&amp;)(&#34;)(&lt;)(&gt;)\"",
-	}
-
 	for _, suiteName := range suiteNames {
 		t.Run(strings.TrimPrefix(suiteName, "~"), func(t *testing.T) {
 			suite, err := loadTestSuite(suiteName)
@@ -39,15 +38,11 @@ func TestCompileGo(t *testing.T) {
 			}
 
 			for _, test := range suite {
-				if want, hasOverride := overrides[suiteName+"/"+test.Name]; hasOverride {
-					test.Expected = want
-				}
-
 				t.Run(test.Name, func(t *testing.T) {
 					const templateName = "MyTemplate"
-					goSource, err := compileGo("main", templateName, test.Template, func(name string) (string, error) {
+					goSource, err := compileGo(templateName, test.Template, func(name string) (string, error) {
 						return test.Partials[name], nil
-					})
+					}, &goOptions{packageName: "main"})
 					if err != nil {
 						t.Fatal("compile:", err)
 					}
@@ -67,26 +62,9 @@ func TestCompileGo(t *testing.T) {
 					if err := os.WriteFile(filepath.Join(tempDir, "main.go"), []byte(runner), 0o666); err != nil {
 						t.Fatal(err)
 					}
-					currentDir, err := os.Getwd()
-					if err != nil {
-						t.Fatal(err)
-					}
-					goMod := "module foo\n" +
-						"require github.com/kagisearch/mustache-codegen v0.1.0\n" +
-						"replace github.com/kagisearch/mustache-codegen => " +
-						filepath.Dir(filepath.Dir(currentDir)) + "\n"
-					if err := os.WriteFile(filepath.Join(tempDir, "go.mod"), []byte(goMod), 0o666); err != nil {
-						t.Fatal(err)
-					}
+					writeGoModule(t, goPath, tempDir)
 
-					c := exec.Command(goPath, "mod", "tidy")
-					c.Dir = tempDir
-					c.Stderr = os.Stderr
-					if err := c.Run(); err != nil {
-						t.Fatal("go mod tidy:", err)
-					}
-
-					c = exec.Command(goPath, "run", ".")
+					c := exec.Command(goPath, "run", ".")
 					c.Dir = tempDir
 					c.Stdin = bytes.NewReader(test.Data)
 					stdout := new(bytes.Buffer)
@@ -104,6 +82,329 @@ func TestCompileGo(t *testing.T) {
 	}
 }
 
+// TestInterpreter verifies that the interpreter renders templates
+// the same way as the generated Go code.
+func TestInterpreter(t *testing.T) {
+	for _, suiteName := range suiteNames {
+		t.Run(strings.TrimPrefix(suiteName, "~"), func(t *testing.T) {
+			suite, err := loadTestSuite(suiteName)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, test := range suite {
+				t.Run(test.Name, func(t *testing.T) {
+					tmpl, err := interp.Parse(test.Template, func(name string) (string, error) {
+						return test.Partials[name], nil
+					})
+					if err != nil {
+						t.Fatal("parse:", err)
+					}
+					var data any
+					if err := json.Unmarshal(test.Data, &data); err != nil {
+						t.Fatal(err)
+					}
+					buf := new(bytes.Buffer)
+					tmpl.Render(buf, data)
+					if got := buf.String(); got != test.Expected {
+						t.Errorf("output:\n%q\nexpected:\n%q", got, test.Expected)
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestGoFuncName(t *testing.T) {
+	tests := []struct {
+		templateName string
+		exported     string
+		unexported   string
+	}{
+		{"foo", "Foo", "foo"},
+		{"foo_bar", "FooBar", "fooBar"},
+		{"MyTemplate", "MyTemplate", "myTemplate"},
+		{"404-page", "Template404Page", "template404Page"},
+		{"my.email", "MyEmail", "myEmail"},
+		{"func", "Func", "func_"},
+		{"any", "Any", "any_"},
+		{"len", "Len", "len_"},
+		{"m", "M", "m_"},
+		{"bytes", "Bytes", "bytes_"},
+		{"filters", "Filters", "filters_"},
+		{"interp", "Interp", "interp_"},
+		{"_", "Template", "template"},
+		{"", "Template", "template"},
+	}
+	for _, test := range tests {
+		if got := goFuncName(test.templateName, true); got != test.exported {
+			t.Errorf("goFuncName(%q, true) = %q; want %q", test.templateName, got, test.exported)
+		}
+		if got := goFuncName(test.templateName, false); got != test.unexported {
+			t.Errorf("goFuncName(%q, false) = %q; want %q", test.templateName, got, test.unexported)
+		}
+	}
+}
+
+func TestCompileGoOptions(t *testing.T) {
+	const source = "Hello, {{>subject}}{{punctuation}}\n"
+	load := func(name string) (string, error) { return "World", nil }
+
+	tests := []struct {
+		name         string
+		templateName string
+		opts         goOptions
+		want         []string
+	}{
+		{
+			name:         "Default",
+			templateName: "404-page",
+			opts:         goOptions{packageName: "foo"},
+			want: []string{
+				"func Template404Page(buf *bytes.Buffer, data any) {",
+				"func _Template404Page_p0(",
+				// "Hello, " + "World" + "\n"
+				"const Template404PageSizeHint = 13\n",
+				"\tbuf.Grow(Template404PageSizeHint)\n",
+			},
+		},
+		{
+			name:         "FuncName",
+			templateName: "404-page",
+			opts:         goOptions{packageName: "foo", funcName: "NotFound"},
+			want:         []string{"func NotFound(buf *bytes.Buffer, data any) {"},
+		},
+		{
+			name:         "Unexported",
+			templateName: "my.email",
+			opts:         goOptions{packageName: "foo", unexported: true},
+			want:         []string{"func myEmail(buf *bytes.Buffer, data any) {"},
+		},
+		{
+			name:         "Receiver",
+			templateName: "page",
+			opts:         goOptions{packageName: "foo", receiver: "*Templates"},
+			want: []string{
+				"func (*Templates) Page(buf *bytes.Buffer, data any) {",
+				"func _Templates_Page_p0(",
+				"const TemplatesPageSizeHint = 13\n",
+			},
+		},
+		{
+			name:         "EscapeMinimal",
+			templateName: "page",
+			opts:         goOptions{packageName: "foo", escaper: mustache.EscapeMinimal},
+			want:         []string{`m.EscapeMinimal.Escape(buf, m.ToString(stack.Lookup("punctuation")))`},
+		},
+		{
+			name:         "BuildTags",
+			templateName: "page",
+			opts:         goOptions{packageName: "foo", buildTags: "!dev"},
+			want:         []string{"//go:build !dev\n\npackage foo\n"},
+		},
+		{
+			name:         "Translator",
+			templateName: "page",
+			opts:         goOptions{packageName: "foo", translator: true},
+			want: []string{
+				"func Page(buf *bytes.Buffer, data any, tr m.Translator) {",
+				"\tstack.SetTranslator(tr)\n",
+			},
+		},
+		{
+			name:         "InterpretTranslator",
+			templateName: "page",
+			opts:         goOptions{packageName: "foo", interpret: true, templatePath: "page.mustache", translator: true},
+			want: []string{
+				"func Page(buf *bytes.Buffer, data any, tr m.Translator) {",
+				"\t\tI18n:       true,\n",
+				"\t\tTranslator: tr,\n",
+			},
+		},
+		{
+			name:         "Translations",
+			templateName: "page",
+			opts: goOptions{
+				packageName: "foo",
+				translations: []*translation{
+					{locale: "de", fallback: true},
+					{locale: "pt-BR", fallback: true},
+				},
+			},
+			want: []string{
+				"const Page_deSizeHint = 13\n",
+				"func Page_de(buf *bytes.Buffer, data any) {",
+				"func _Page_de_p0(",
+				"const Page_pt_BRSizeHint = 13\n",
+				"func Page_pt_BR(buf *bytes.Buffer, data any) {",
+				"func _Page_pt_BR_p0(",
+			},
+		},
+		{
+			name:         "Interpret",
+			templateName: "page",
+			opts: goOptions{
+				packageName:  "foo",
+				buildTags:    "dev",
+				interpret:    true,
+				templatePath: "templates/page.mustache",
+			},
+			want: []string{
+				"//go:build dev\n\npackage foo\n",
+				"func Page(buf *bytes.Buffer, data any) {",
+				`filepath.Join(filepath.Dir(file), "templates/page.mustache")`,
+				"interp.RenderFileOptions(buf, path, data, &interp.RenderOptions{\n\t\tEscaper: m.EscapeQuotes,\n\t}",
+				"const PageSizeHint = 13\n",
+			},
+		},
+		{
+			name:         "InterpretMinifyHTML",
+			templateName: "page",
+			opts:         goOptions{packageName: "foo", interpret: true, templatePath: "page.mustache", minifyHTML: true},
+			want:         []string{"\t\tMinifyHTML: true,\n"},
+		},
+		{
+			name:         "InterpretStrict",
+			templateName: "page",
+			opts:         goOptions{packageName: "foo", interpret: true, templatePath: "page.mustache", strict: true},
+			want:         []string{"\t\tStrict:  true,\n"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := compileGo(test.templateName, source, load, &test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.want {
+				if !bytes.Contains(got, []byte(want)) {
+					t.Errorf("generated code does not contain %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+
+	badOpts := []goOptions{
+		{packageName: "foo", funcName: "my-func"},
+		{packageName: "foo", funcName: "func"},
+		{packageName: "foo", funcName: "_"},
+		{packageName: "foo", funcName: "any"},
+		{packageName: "foo", funcName: "bytes"},
+		{packageName: "foo", funcName: "m"},
+		{packageName: "foo", funcName: "filters"},
+		{packageName: "foo", receiver: "**T"},
+		{packageName: "foo", receiver: "pkg.T"},
+		{packageName: "foo", buildTags: "foo &&"},
+		{packageName: "foo", interpret: true},
+		{packageName: "foo", escaper: 99},
+	}
+	for _, opts := range badOpts {
+		if _, err := compileGo("foo", source, load, &opts); err == nil {
+			t.Errorf("compileGo(..., %+v) did not return an error", opts)
+		}
+	}
+}
+
+// specBenchDir is the directory of the spec benchmark package,
+// which has a function generated for each of the specification tests.
+var specBenchDir = filepath.Join("..", "..", "bench", "spec")
+
+// TestSpecBench checks that the generated files in [specBenchDir] are up to date.
+// Regenerate them with:
+//
+//	go test ./cmd/mustache-codegen -run=TestSpecBench -update-spec-bench
+func TestSpecBench(t *testing.T) {
+	files, err := generateSpecBench()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(specBenchDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if name == "spec.go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if _, ok := files[name]; ok {
+			continue
+		}
+		if *updateSpecBench {
+			if err := os.Remove(filepath.Join(specBenchDir, name)); err != nil {
+				t.Error(err)
+			}
+		} else {
+			t.Errorf("%s is not generated (run with -update-spec-bench)", name)
+		}
+	}
+	for name, content := range files {
+		path := filepath.Join(specBenchDir, name)
+		if *updateSpecBench {
+			if err := os.WriteFile(path, content, 0o666); err != nil {
+				t.Error(err)
+			}
+			continue
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("%v (run with -update-spec-bench)", err)
+		} else if !bytes.Equal(got, content) {
+			t.Errorf("%s is out of date (run with -update-spec-bench)", path)
+		}
+	}
+}
+
+// generateSpecBench returns the contents of the generated files
+// of the package in [specBenchDir] keyed by file name.
+func generateSpecBench() (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	table := new(bytes.Buffer)
+	fmt.Fprintln(table, "// Code generated by mustache-codegen tests. DO NOT EDIT.")
+	fmt.Fprintln(table)
+	fmt.Fprintln(table, "package spec")
+	fmt.Fprintln(table)
+	fmt.Fprintln(table, "var tests = []testCase{")
+	for _, suiteName := range suiteNames {
+		suite, err := loadTestSuite(suiteName)
+		if err != nil {
+			return nil, err
+		}
+		suiteName = strings.TrimPrefix(suiteName, "~")
+		seen := make(map[string]int)
+		for _, test := range suite {
+			// The specification has some tests with duplicate names.
+			baseName := suiteName + "_" + test.Name
+			seen[baseName]++
+			if n := seen[baseName]; n > 1 {
+				baseName += fmt.Sprint(n)
+			}
+			opts := &goOptions{
+				packageName: "spec",
+				funcName:    goFuncName(baseName, false),
+			}
+			goSource, err := compileGo(test.Name, test.Template, func(name string) (string, error) {
+				return test.Partials[name], nil
+			}, opts)
+			if err != nil {
+				return nil, fmt.Errorf("%s/%s: %v", suiteName, test.Name, err)
+			}
+			// Name the file after the function
+			// so that no test name ends up as a "_test" or build constraint suffix.
+			files[strings.ToLower(opts.funcName)+".go"] = goSource
+			fmt.Fprintf(table, "\t{suite: %q, name: %q, render: %s, data: %q},\n",
+				suiteName, test.Name, opts.funcName, test.Data)
+		}
+	}
+	fmt.Fprintln(table, "}")
+	formatted, err := gofmt.Source(table.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	files["tests.go"] = formatted
+	return files, nil
+}
+
 // FuzzCompileGoDeterminism verifies that Go code generation
 // yields the same code each time it is called with the same template.
 func FuzzCompileGoDeterminism(f *testing.F) {
@@ -119,11 +420,12 @@ func FuzzCompileGoDeterminism(f *testing.F) {
 
 	f.Fuzz(func(t *testing.T, s string) {
 		load := func(name string) (string, error) { return "", nil }
-		got1, err := compileGo("foo", "bar", s, load)
+		opts := &goOptions{packageName: "foo"}
+		got1, err := compileGo("bar", s, load, opts)
 		if err != nil {
 			t.Skip("Invalid template:", err)
 		}
-		got2, err := compileGo("foo", "bar", s, load)
+		got2, err := compileGo("bar", s, load, opts)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -0,0 +1,281 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// goTypeLoader finds the declarations of Go types in source code
+// so that template names can be resolved to the struct fields they look up.
+// It only reads syntax, so it does not need the packages to build.
+type goTypeLoader struct {
+	fset *token.FileSet
+	// packages maps package directories to their declarations.
+	packages map[string]*goPackage
+}
+
+// goPackage is the type declarations of a Go package.
+type goPackage struct {
+	dir  string
+	name string
+	// types maps the names of the package's types to their declarations.
+	types map[string]goTypeDecl
+}
+
+type goTypeDecl struct {
+	spec *ast.TypeSpec
+	file *ast.File
+}
+
+// goTypeRef is a type expression in the Go file it appears in.
+type goTypeRef struct {
+	pkg  *goPackage
+	file *ast.File
+	expr ast.Expr
+}
+
+// goField is a struct field that a template name refers to.
+type goField struct {
+	name string
+	typ  *goTypeRef
+	// doc is the field's doc comment.
+	doc string
+}
+
+func (f *goField) String() string {
+	return "field " + f.name + " " + types.ExprString(f.typ.expr)
+}
+
+func newGoTypeLoader() *goTypeLoader {
+	return &goTypeLoader{
+		fset:     token.NewFileSet(),
+		packages: make(map[string]*goPackage),
+	}
+}
+
+// dataType returns the type of template data as accepted by [goDataType].
+// Types without an import path are declared in the package in dir.
+func (l *goTypeLoader) dataType(dataType, dir string) (*goTypeRef, error) {
+	var packageName string
+	local, localErr := l.load(".", dir)
+	if localErr == nil {
+		packageName = local.name
+	}
+	importPath, typeExpr, err := goDataType(dataType, packageName)
+	if err != nil {
+		return nil, err
+	}
+	pkg := local
+	if importPath != "" {
+		pkg, err = l.load(importPath, dir)
+		if err != nil {
+			return nil, err
+		}
+		// Refer to the type by its name in its own package.
+		rest := strings.TrimLeft(typeExpr, "*[]")
+		typeExpr = typeExpr[:len(typeExpr)-len(rest)] + rest[strings.LastIndex(rest, ".")+1:]
+	} else if localErr != nil {
+		return nil, localErr
+	}
+	expr, err := parser.ParseExpr(typeExpr)
+	if err != nil {
+		return nil, err
+	}
+	return &goTypeRef{pkg: pkg, expr: expr}, nil
+}
+
+// load returns the declarations of the package with the given import path,
+// resolved in the module that contains dir.
+// The import path "." is the package in dir.
+func (l *goTypeLoader) load(importPath, dir string) (*goPackage, error) {
+	ctxt := build.Default
+	ctxt.Dir = dir
+	var bp *build.Package
+	var err error
+	if importPath == "." {
+		bp, err = ctxt.ImportDir(dir, 0)
+	} else {
+		bp, err = ctxt.Import(importPath, dir, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if pkg := l.packages[bp.Dir]; pkg != nil {
+		return pkg, nil
+	}
+	pkg := &goPackage{dir: bp.Dir, name: bp.Name, types: make(map[string]goTypeDecl)}
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(l.fset, filepath.Join(bp.Dir, name), nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				if spec.Doc == nil && len(gen.Specs) == 1 {
+					spec.Doc = gen.Doc
+				}
+				pkg.types[spec.Name.Name] = goTypeDecl{spec: spec, file: f}
+			}
+		}
+	}
+	l.packages[bp.Dir] = pkg
+	return pkg, nil
+}
+
+// underlying follows pointers and named types to the definition of t,
+// stopping at types that are not declared in source, like string.
+func (l *goTypeLoader) underlying(t *goTypeRef) *goTypeRef {
+	// Limit the number of steps in case of cycles like "type T *T".
+	for range 100 {
+		switch expr := t.expr.(type) {
+		case *ast.StarExpr:
+			t = &goTypeRef{pkg: t.pkg, file: t.file, expr: expr.X}
+		case *ast.ParenExpr:
+			t = &goTypeRef{pkg: t.pkg, file: t.file, expr: expr.X}
+		case *ast.Ident:
+			decl, ok := t.pkg.types[expr.Name]
+			if !ok {
+				return t
+			}
+			t = &goTypeRef{pkg: t.pkg, file: decl.file, expr: decl.spec.Type}
+		case *ast.SelectorExpr:
+			pkg := l.imported(t, expr.X)
+			if pkg == nil {
+				return t
+			}
+			decl, ok := pkg.types[expr.Sel.Name]
+			if !ok {
+				return t
+			}
+			t = &goTypeRef{pkg: pkg, file: decl.file, expr: decl.spec.Type}
+		default:
+			return t
+		}
+	}
+	return t
+}
+
+// imported returns the package that the identifier x refers to
+// in the file that t appears in.
+func (l *goTypeLoader) imported(t *goTypeRef, x ast.Expr) *goPackage {
+	id, ok := x.(*ast.Ident)
+	if !ok || t.file == nil {
+		return nil
+	}
+	for _, imp := range t.file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		if imp.Name != nil && imp.Name.Name != id.Name {
+			continue
+		}
+		pkg, err := l.load(path, t.pkg.dir)
+		if err == nil && (imp.Name != nil || pkg.name == id.Name) {
+			return pkg
+		}
+	}
+	return nil
+}
+
+// field returns the field with the given name of the struct type t,
+// including fields promoted from embedded structs,
+// or nil if there is no such field.
+func (l *goTypeLoader) field(t *goTypeRef, name string) *goField {
+	return l.fieldDepth(t, name, 0)
+}
+
+func (l *goTypeLoader) fieldDepth(t *goTypeRef, name string, depth int) *goField {
+	if t == nil || depth > 10 {
+		return nil
+	}
+	u := l.underlying(t)
+	st, ok := u.expr.(*ast.StructType)
+	if !ok {
+		return nil
+	}
+	var embedded []*goTypeRef
+	for _, f := range st.Fields.List {
+		typ := &goTypeRef{pkg: u.pkg, file: u.file, expr: f.Type}
+		if len(f.Names) == 0 {
+			embedded = append(embedded, typ)
+			// An embedded field is named after its type.
+			if embeddedName(f.Type) != name {
+				continue
+			}
+		} else if !slices.ContainsFunc(f.Names, func(id *ast.Ident) bool { return id.Name == name }) {
+			continue
+		}
+		doc := f.Doc.Text()
+		if doc == "" {
+			doc = f.Comment.Text()
+		}
+		return &goField{name: name, typ: typ, doc: strings.TrimSpace(doc)}
+	}
+	// Fields of embedded structs are promoted.
+	for _, e := range embedded {
+		if f := l.fieldDepth(e, name, depth+1); f != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// embeddedName returns the field name of an embedded type.
+func embeddedName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(expr.X)
+	case *ast.Ident:
+		return expr.Name
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedName(expr.X)
+	default:
+		return ""
+	}
+}
+
+// lookup returns the field that a template name like "a.b" refers to
+// given the types of the context stack, from outermost to innermost.
+// Contexts of unknown types are nil.
+func (l *goTypeLoader) lookup(contexts []*goTypeRef, name string) *goField {
+	parts := strings.Split(name, ".")
+	var f *goField
+	for i := len(contexts) - 1; i >= 0 && f == nil; i-- {
+		f = l.field(contexts[i], parts[0])
+	}
+	for _, part := range parts[1:] {
+		if f == nil {
+			return nil
+		}
+		f = l.field(f.typ, part)
+	}
+	return f
+}
+
+// elem returns the type of the context that a section over a value of type t pushes:
+// the element type for slices and arrays, and t itself otherwise.
+func (l *goTypeLoader) elem(t *goTypeRef) *goTypeRef {
+	u := l.underlying(t)
+	if a, ok := u.expr.(*ast.ArrayType); ok {
+		return &goTypeRef{pkg: u.pkg, file: u.file, expr: a.Elt}
+	}
+	return t
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kagisearch/mustache-codegen/internal/syntax"
+)
+
+// translation is a message catalog for translating templates at compile time.
+type translation struct {
+	// locale is the catalog's locale, such as "de" or "pt-BR".
+	locale string
+	// messages maps messages to their translations.
+	messages map[string]string
+	// fallback is whether to render messages that have no translation untranslated
+	// instead of returning an error.
+	fallback bool
+	// missing, if not nil, is called for each message without a translation
+	// that falls back to the untranslated message.
+	// pos is the position of the message as returned by [translation.position].
+	missing func(pos, message string)
+	// file, if not nil, returns the name of the file that the template
+	// or one of its partials is read from for messages.
+	// partial is the name of the partial,
+	// or the empty string for the template itself.
+	file func(template, partial string) string
+}
+
+// position returns the position of a line of a template or one of its partials
+// for messages, like "page.mustache:3".
+func (tr *translation) position(template, partial string, line int) string {
+	file := template
+	switch {
+	case tr.file != nil:
+		file = tr.file(template, partial)
+	case partial != "":
+		file = partial
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
+
+// translate returns a copy of tags with its [syntax.Translation] tags
+// replaced by the tags of their translations.
+// template is the name of the template being compiled,
+// and partial is the name of the partial that tags were parsed from,
+// or the empty string for the template itself.
+// It returns an error if a translation uses a variable that its message does not,
+// or if a message has no translation and tr.fallback is false.
+func (tr *translation) translate(template, partial string, tags []syntax.Tag) ([]syntax.Tag, error) {
+	var firstErr error
+	var walk func(tags []syntax.Tag) []syntax.Tag
+	walk = func(tags []syntax.Tag) []syntax.Tag {
+		result := make([]syntax.Tag, 0, len(tags))
+		for _, t := range tags {
+			if t.Type != syntax.Translation {
+				t.Body = walk(t.Body)
+				result = append(result, t)
+				continue
+			}
+			pos := tr.position(template, partial, t.Line)
+			translated, ok := tr.messages[t.S]
+			if !ok {
+				if tr.fallback && tr.missing != nil {
+					tr.missing(pos, t.S)
+				}
+				if firstErr == nil && !tr.fallback {
+					firstErr = fmt.Errorf("%s: no %s translation for %q", pos, tr.locale, t.S)
+				}
+				translated = t.S
+			}
+			translatedTags := syntax.ParseMessage(translated)
+			if err := checkTranslationVariables(t.S, translatedTags); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("%s: %s translation of %q %v", pos, tr.locale, t.S, err)
+			}
+			result = append(result, translatedTags...)
+		}
+		return result
+	}
+	tags = walk(tags)
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return tags, nil
+}
+
+// checkTranslationVariables returns an error if the tags of a translation
+// contain a variable that message does not contain
+// with the same name and escaping.
+func checkTranslationVariables(message string, translated []syntax.Tag) error {
+	type variable struct {
+		name string
+		raw  bool
+	}
+	vars := make(map[variable]bool)
+	for _, t := range syntax.ParseMessage(message) {
+		if t.Type == syntax.Variable || t.Type == syntax.RawVariable {
+			vars[variable{t.S, t.Type == syntax.RawVariable}] = true
+		}
+	}
+	for _, t := range translated {
+		if t.Type != syntax.Variable && t.Type != syntax.RawVariable {
+			continue
+		}
+		v := variable{t.S, t.Type == syntax.RawVariable}
+		if vars[v] {
+			continue
+		}
+		tag := "{{" + t.S + "}}"
+		if v.raw {
+			tag = "{{&" + t.S + "}}"
+		}
+		return fmt.Errorf("uses %s, which is not in the message", tag)
+	}
+	return nil
+}
+
+// localeSuffix returns the suffix of the names of functions
+// generated for locale, or an error if the locale is not a valid name.
+func localeSuffix(locale string) (string, error) {
+	if locale == "" || strings.Trim(locale, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-") != "" {
+		return "", fmt.Errorf("invalid locale %q", locale)
+	}
+	return "_" + strings.ReplaceAll(locale, "-", "_"), nil
+}
+
+// loadCatalog reads a message catalog from a gettext .po file
+// or a .json file that contains an object that maps messages to translations.
+func loadCatalog(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if filepath.Ext(path) == ".json" {
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		return messages, nil
+	}
+	messages, err := parsePO(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%v", path, err)
+	}
+	return messages, nil
+}
+
+// parsePO parses the messages in a gettext .po file.
+// Entries with an empty translation, fuzzy entries, and the header are skipped.
+func parsePO(data []byte) (map[string]string, error) {
+	messages := make(map[string]string)
+	var msgid, msgstr strings.Builder
+	// field is the string that continuation lines append to.
+	var field *strings.Builder
+	fuzzy := false
+	seenEntry := false
+	flush := func() {
+		if seenEntry && !fuzzy && msgid.Len() > 0 && msgstr.Len() > 0 {
+			messages[msgid.String()] = msgstr.String()
+		}
+		msgid.Reset()
+		msgstr.Reset()
+		field = nil
+		fuzzy = false
+		seenEntry = false
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for lineno := 1; s.Scan(); lineno++ {
+		line := strings.TrimSpace(s.Text())
+		var quoted string
+		switch {
+		case line == "":
+			flush()
+			continue
+		case strings.HasPrefix(line, "#"):
+			if seenEntry {
+				flush()
+			}
+			if strings.HasPrefix(line, "#,") && strings.Contains(line, "fuzzy") {
+				fuzzy = true
+			}
+			continue
+		case strings.HasPrefix(line, "msgid "):
+			if seenEntry {
+				flush()
+			}
+			seenEntry = true
+			field = &msgid
+			quoted = strings.TrimPrefix(line, "msgid ")
+		case strings.HasPrefix(line, "msgstr "):
+			field = &msgstr
+			quoted = strings.TrimPrefix(line, "msgstr ")
+		case strings.HasPrefix(line, `"`) && field != nil:
+			quoted = line
+		default:
+			return nil, fmt.Errorf("%d: unsupported line", lineno)
+		}
+		str, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("%d: invalid string %s", lineno, quoted)
+		}
+		field.WriteString(str)
+	}
+	flush()
+	return messages, s.Err()
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/kagisearch/mustache-codegen/internal/syntax"
+)
+
+func TestParsePO(t *testing.T) {
+	const po = `# Translations
+msgid ""
+msgstr ""
+"Language: de\n"
+
+#: page.mustache:1
+msgid "Hello, {{name}}!"
+msgstr "Hallo, "
+"{{name}}!"
+
+#, fuzzy
+msgid "Bye"
+msgstr "Tschüss"
+
+msgid "Untranslated"
+msgstr ""
+
+msgid "Say \"hi\""
+msgstr "Sag \"hallo\""
+`
+	got, err := parsePO([]byte(po))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"Hello, {{name}}!": "Hallo, {{name}}!",
+		`Say "hi"`:         `Sag "hallo"`,
+	}
+	if !maps.Equal(got, want) {
+		t.Errorf("parsePO(...) = %q; want %q", got, want)
+	}
+
+	if _, err := parsePO([]byte("msgid \"a\"\nmsgstr b\n")); err == nil {
+		t.Error("parsePO did not return an error for an unquoted string")
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tags, err := syntax.ParseOptions("{{#items}}{{#_i18n}}Hello, {{name}}!{{/_i18n}}{{/items}}\n{{#_i18n}}Bye{{/_i18n}}", &syntax.Options{I18n: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var missing []string
+	tr := &translation{
+		locale:   "de",
+		messages: map[string]string{"Hello, {{name}}!": "Hallo, {{ name }}!"},
+		fallback: true,
+		missing: func(pos, message string) {
+			missing = append(missing, fmt.Sprintf("%s %s", pos, message))
+		},
+	}
+	got, err := tr.translate("page", "footer", tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []syntax.Tag{
+		{Type: syntax.IndentPoint},
+		{Type: syntax.Section, S: "items", Body: []syntax.Tag{
+			{Type: syntax.Literal, S: "Hallo, "},
+			{Type: syntax.Variable, S: "name"},
+			{Type: syntax.Literal, S: "!"},
+		}},
+		{Type: syntax.Literal, S: "\n"},
+		{Type: syntax.IndentPoint},
+		{Type: syntax.Literal, S: "Bye"},
+	}
+	if !slices.EqualFunc(got, want, syntax.TagsEqual) {
+		t.Errorf("translate(...) = %+v; want %+v", got, want)
+	}
+	if want := []string{"footer:2 Bye"}; !slices.Equal(missing, want) {
+		t.Errorf("missing translations = %q; want %q", missing, want)
+	}
+
+	tr.fallback = false
+	tr.file = func(template, partial string) string {
+		return "templates/" + template + ".mustache"
+	}
+	if _, err := tr.translate("page", "", tags); err == nil || !strings.Contains(err.Error(), `templates/page.mustache:2: no de translation for "Bye"`) {
+		t.Errorf("translate without fallback returned error %v; want error about missing translation", err)
+	}
+
+	// Translations may only use the variables of their messages.
+	for _, translated := range []string{
+		"Hallo {{}} {{#x}}",
+		"Hallo, {{&name}}!",
+		"Hallo, {{user}}!",
+	} {
+		tr := &translation{
+			locale:   "de",
+			messages: map[string]string{"Hello, {{name}}!": translated, "Bye": "Tschüss"},
+		}
+		_, err := tr.translate("page", "", tags)
+		if err == nil || !strings.Contains(err.Error(), "page:1: de translation") {
+			t.Errorf("translate with translation %q returned error %v; want error about its variables", translated, err)
+		}
+	}
+}
+
+func TestCompileWithoutI18n(t *testing.T) {
+	// Without a translator or catalogs, _i18n is an ordinary section,
+	// so it may contain any tag.
+	const source = "{{#_i18n}}{{#a}}b{{/a}}{{/_i18n}}"
+	load := func(name string) (string, error) { return "", nil }
+	if _, err := compileGo("page", source, load, &goOptions{packageName: "foo"}); err != nil {
+		t.Error("compileGo:", err)
+	}
+	if _, err := compileJS("page", source, load, &jsOptions{}); err != nil {
+		t.Error("compileJS:", err)
+	}
+	if _, err := compileGo("page", source, load, &goOptions{packageName: "foo", translator: true}); err == nil {
+		t.Error("compileGo with a translator did not return an error")
+	}
+	if _, err := compileJS("page", source, load, &jsOptions{translator: true}); err == nil {
+		t.Error("compileJS with a translator did not return an error")
+	}
+	tr := &translation{locale: "de", messages: map[string]string{}}
+	if _, err := compileGo("page", source, load, &goOptions{packageName: "foo", translations: []*translation{tr}}); err == nil {
+		t.Error("compileGo with a catalog did not return an error")
+	}
+}
@@ -7,47 +7,707 @@ import (
 	"bytes"
 	_ "embed"
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
 	"text/template"
+
+	"github.com/kagisearch/mustache-codegen/go/mustache"
+	"github.com/kagisearch/mustache-codegen/internal/syntax"
 )
 
 //go:embed prelude.js
 var prelude string
 
-func compileJS(source string, load func(name string) (string, error)) ([]byte, error) {
-	tags, err := parse(string(source))
+// jsOptions is the set of options for [compileJS].
+type jsOptions struct {
+	// escaper is the set of characters escaped in variables.
+	escaper mustache.Escaper
+	// stream is whether to generate an async generator function
+	// that yields chunks of output instead of a function that returns a string.
+	stream bool
+	// format is the module format of the generated code:
+	// "esm" (the default if empty), "cjs", or "iife".
+	format string
+	// name is the name the generated function is exported as.
+	// For the "esm" and "cjs" formats, it is optional
+	// and the function is the default export if it is empty.
+	// For the "iife" format, it is the name of the global variable
+	// and defaults to a name derived from the template name.
+	name string
+	// runtime is the path of a module generated by [compileJSRuntime]
+	// to import the prelude's helpers from.
+	// If it is empty, the helpers are included in the generated module.
+	runtime string
+	// pretty is whether to format the generated code for reading.
+	pretty bool
+	// minifyHTML is whether to minify the HTML in the templates' text
+	// with [syntax.MinifyHTML].
+	minifyHTML bool
+	// frontMatter is whether the templates may start with front matter,
+	// which is exported as metadata instead of rendered.
+	frontMatter bool
+	// translator is whether {{#_i18n}} sections are messages
+	// that the generated functions translate at run time
+	// with a function passed as their second argument.
+	translator bool
+	// translations is the list of catalogs to translate the templates with
+	// at compile time.
+	// If it is not empty, a function is exported for each translation
+	// of each template with the locale appended to its name (e.g. fooBar_de).
+	translations []*translation
+	// filters is the module whose functions variables can be filtered with.
+	// If it is nil, filter pipelines are not recognized.
+	filters *filterPackage
+	// strict is whether variables that cannot be found throw an error.
+	strict bool
+}
+
+// jsReservedWords is the set of JavaScript reserved words,
+// which cannot be used as names.
+var jsReservedWords = map[string]bool{
+	"await": true, "break": true, "case": true, "catch": true, "class": true,
+	"const": true, "continue": true, "debugger": true, "default": true, "delete": true,
+	"do": true, "else": true, "enum": true, "export": true, "extends": true,
+	"false": true, "finally": true, "for": true, "function": true, "if": true,
+	"implements": true, "import": true, "in": true, "instanceof": true, "interface": true,
+	"let": true, "new": true, "null": true, "package": true, "private": true,
+	"protected": true, "public": true, "return": true, "static": true, "super": true,
+	"switch": true, "this": true, "throw": true, "true": true, "try": true,
+	"typeof": true, "var": true, "void": true, "while": true, "with": true,
+	"yield": true, "arguments": true, "eval": true,
+}
+
+// jsPreludeNames matches the declarations of the names defined by the prelude.
+var jsPreludeNames = regexp.MustCompile(`(?m)(?:^const |, )([A-Za-z_$][A-Za-z0-9_$]*) ?=`)
+
+// isJSInternalName reports whether name is used at the top level of generated code.
+func isJSInternalName(name string) bool {
+	if strings.HasPrefix(name, "p") && name != "p" && strings.Trim(name[1:], "0123456789") == "" {
+		return true
+	}
+	if name == "filters" {
+		return true
+	}
+	for _, m := range jsPreludeNames.FindAllStringSubmatch(prelude, -1) {
+		if m[1] == name {
+			return true
+		}
+	}
+	return false
+}
+
+// jsName derives a JavaScript name from a template or filter name
+// as described by [camelName].
+// Unlike [goFuncName], it keeps names that are only reserved in Go;
+// generated declarations reject names in [jsReservedWords].
+func jsName(templateName string) string {
+	return camelName(templateName, false)
+}
+
+// isJSTemplateFuncName reports whether name is the name of
+// a template function in a bundle generated by [compileJSBundle].
+func isJSTemplateFuncName(name string) bool {
+	return strings.HasPrefix(name, "t") && name != "t" && strings.Trim(name[1:], "0123456789") == ""
+}
+
+// jsHelper is a function or constant declared by the prelude.
+type jsHelper struct {
+	name string
+	// decl is the prelude's line that declares the helper.
+	decl string
+}
+
+// jsHelpers is the list of the prelude's helpers in order.
+// The escaper's line is named after match_html.
+var jsHelpers = parseJSHelpers(prelude)
+
+func parseJSHelpers(prelude string) []jsHelper {
+	var helpers []jsHelper
+	for _, line := range strings.Split(strings.TrimSuffix(prelude, "\n"), "\n") {
+		m := jsPreludeNames.FindStringSubmatch(line)
+		if m == nil {
+			panic("prelude line does not declare a name: " + line)
+		}
+		helpers = append(helpers, jsHelper{name: m[1], decl: line})
+	}
+	return helpers
+}
+
+// jsHelperDeps maps the prelude's helpers to the other helpers they use.
+var jsHelperDeps = map[string][]string{
+	"esc":   {"match_html"},
+	"look":  {"has", "call"},
+	"prop":  {"has", "call"},
+	"f":     {"arr", "it"},
+	"each":  {"arr", "it"},
+	"aeach": {"it"},
+	"tpath": {"prop", "look"},
+	"i18n":  {"tre", "tpath", "esc"},
+	"ai18n": {"tre", "tpath", "esc"},
+}
+
+// jsEscapers maps each escaper to the prelude line that configures esc.
+// prelude.js contains the line for [mustache.EscapeQuotes].
+var jsEscapers = map[mustache.Escaper]string{
+	mustache.EscapeMinimal: `const match_html = /["&<>]/, esc_q = false`,
+	mustache.EscapeQuotes:  `const match_html = /["&'<>]/, esc_q = true`,
+	mustache.EscapeNone:    `const match_html = /(?!)/, esc_q = false`,
+}
+
+func compileJS(templateName string, source string, load func(name string) (string, error), opts *jsOptions) ([]byte, error) {
+	name := opts.name
+	switch opts.format {
+	case "", "esm", "cjs":
+	case "iife":
+		if name == "" {
+			name = jsName(templateName)
+		}
+	default:
+		return nil, fmt.Errorf("unknown JavaScript format %q", opts.format)
+	}
+	if name != "" && (!isJSIdentifier(name) || jsReservedWords[name]) {
+		return nil, fmt.Errorf("invalid JavaScript name %q", name)
+	}
+	if name != "" && (opts.format == "" || opts.format == "esm") && isJSInternalName(name) {
+		return nil, fmt.Errorf("JavaScript name %q conflicts with generated code", name)
+	}
+	if len(opts.translations) > 0 {
+		// Export the template's translations like a bundle of templates.
+		bundleOpts := *opts
+		bundleOpts.name = name
+		return compileJSBundle([]jsTemplate{{name: templateName, source: source, load: load}}, &bundleOpts)
+	}
+
+	g := &jsGenerator{stream: opts.stream, filters: opts.filters}
+	m := &jsModule{namespaces: make(map[string]*jsNamespace), minifyHTML: opts.minifyHTML, frontMatter: opts.frontMatter, filters: opts.filters != nil, i18n: opts.translator || len(opts.translations) > 0}
+	tags, partialFuncNames, opts, err := m.add(jsTemplate{name: templateName, source: source, load: load}, opts)
+	if err != nil {
+		return nil, err
+	}
+	escaper := jsEscapers[opts.escaper]
+	if escaper == "" {
+		return nil, fmt.Errorf("unknown escaper %d", opts.escaper)
+	}
+	meta, hasMeta, err := templateMeta(tags)
 	if err != nil {
 		return nil, err
 	}
+	if hasMeta && name == "meta" && opts.format != "iife" {
+		return nil, fmt.Errorf("JavaScript name %q conflicts with the front matter export", name)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := m.writePartials(buf, g); err != nil {
+		return nil, err
+	}
+	switch {
+	case opts.format == "iife" && hasMeta:
+		// The front matter is a property of the global function.
+		buf.WriteString("return Object.assign(")
+	case opts.format == "iife":
+		buf.WriteString("return ")
+	case opts.format == "cjs" && name == "":
+		buf.WriteString("module.exports=")
+	case opts.format == "cjs":
+		fmt.Fprintf(buf, "exports.%s=", name)
+	case name == "":
+		buf.WriteString("export default ")
+	default:
+		buf.WriteString("export ")
+	}
+	g.partialFuncNames = partialFuncNames
+	g.translations = m.translations
+	g.strict = opts.strict
+	if err := g.writeTemplateFunc(buf, name != "" && opts.format != "iife" && opts.format != "cjs", name, tags); err != nil {
+		return nil, err
+	}
+	if hasMeta {
+		switch {
+		case opts.format == "iife":
+			fmt.Fprintf(buf, ",{meta:%s})", jsMetaLiteral(meta))
+		case opts.format == "cjs" && name == "":
+			fmt.Fprintf(buf, "\nmodule.exports.meta=%s", jsMetaLiteral(meta))
+		case opts.format == "cjs":
+			fmt.Fprintf(buf, "\nexports.meta=%s", jsMetaLiteral(meta))
+		default:
+			fmt.Fprintf(buf, "\nexport const meta=%s", jsMetaLiteral(meta))
+		}
+	}
+	if opts.format == "iife" {
+		buf.WriteString("\n")
+	}
+	return writeJSModule(g, opts, escaper, name, buf.Bytes())
+}
+
+// jsTemplate is a template to compile into a JavaScript bundle.
+type jsTemplate struct {
+	// name is the template's name,
+	// from which the name it is exported as is derived.
+	name   string
+	source string
+	// dir identifies the directory partials are loaded from.
+	// Templates with the same dir share their partials' functions.
+	dir  string
+	load func(name string) (string, error)
+	// translation is the catalog to translate the template with, if any.
+	translation *translation
+}
+
+// compileJSBundle compiles several templates into a single module
+// that includes the prelude and each partial function once.
+// Each template is exported by a name derived from its template name.
+// If opts.name is set, the templates are instead exported
+// as properties of an object with that name.
+// For the "iife" format, the object is always created
+// and opts.name defaults to "templates".
+// If opts.translations is not empty, each template is exported
+// once for each translation instead.
+func compileJSBundle(templates []jsTemplate, opts *jsOptions) ([]byte, error) {
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("no templates to bundle")
+	}
+	name := opts.name
+	switch opts.format {
+	case "", "esm", "cjs":
+	case "iife":
+		if name == "" {
+			name = "templates"
+		}
+	default:
+		return nil, fmt.Errorf("unknown JavaScript format %q", opts.format)
+	}
+	if name != "" && (!isJSIdentifier(name) || jsReservedWords[name]) {
+		return nil, fmt.Errorf("invalid JavaScript name %q", name)
+	}
+	if name != "" && (opts.format == "" || opts.format == "esm") && (isJSInternalName(name) || isJSTemplateFuncName(name)) {
+		return nil, fmt.Errorf("JavaScript name %q conflicts with generated code", name)
+	}
+
+	if len(opts.translations) > 0 {
+		var translated []jsTemplate
+		for _, t := range templates {
+			for _, tr := range opts.translations {
+				t.translation = tr
+				translated = append(translated, t)
+			}
+		}
+		templates = translated
+	}
+
+	g := &jsGenerator{stream: opts.stream, filters: opts.filters}
+	m := &jsModule{namespaces: make(map[string]*jsNamespace), minifyHTML: opts.minifyHTML, frontMatter: opts.frontMatter, filters: opts.filters != nil, i18n: opts.translator || len(opts.translations) > 0}
+	exportNames := make([]string, len(templates))
+	tags := make([][]syntax.Tag, len(templates))
+	partialFuncNames := make([]map[string]string, len(templates))
+	templateOpts := make([]*jsOptions, len(templates))
+	for i, t := range templates {
+		exportNames[i] = jsName(t.name)
+		if t.translation != nil {
+			suffix, err := localeSuffix(t.translation.locale)
+			if err != nil {
+				return nil, err
+			}
+			exportNames[i] += suffix
+		}
+		if !isJSIdentifier(exportNames[i]) || jsReservedWords[exportNames[i]] {
+			return nil, fmt.Errorf("%s: cannot derive a JavaScript name from the template name", t.name)
+		}
+		if j := slices.Index(exportNames[:i], exportNames[i]); j != -1 {
+			return nil, fmt.Errorf("%s and %s have the same JavaScript name %s", templates[j].name, t.name, exportNames[i])
+		}
+		var err error
+		tags[i], partialFuncNames[i], templateOpts[i], err = m.add(t, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", t.name, err)
+		}
+		// The escaper is configured once for the whole module.
+		if e := templateOpts[i].escaper; e != templateOpts[0].escaper {
+			return nil, fmt.Errorf("%s and %s escape variables differently", templates[0].name, t.name)
+		}
+	}
+	escaper := jsEscapers[templateOpts[0].escaper]
+	if escaper == "" {
+		return nil, fmt.Errorf("unknown escaper %d", templateOpts[0].escaper)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := m.writePartials(buf, g); err != nil {
+		return nil, err
+	}
+	g.translations = m.translations
+	for i := range templates {
+		g.partialFuncNames = partialFuncNames[i]
+		g.strict = templateOpts[i].strict
+		if err := g.writeTemplateFunc(buf, true, fmt.Sprintf("t%d", i), tags[i]); err != nil {
+			return nil, err
+		}
+		buf.WriteString("\n")
+	}
+
+	exports := new(strings.Builder)
+	for i, exportName := range exportNames {
+		if i > 0 {
+			exports.WriteString(",")
+		}
+		fmt.Fprintf(exports, "%s:t%d", exportName, i)
+	}
+	// Templates with front matter also export it
+	// by the template's name followed by "Meta".
+	var metaNames, metaLiterals []string
+	for i, t := range templates {
+		meta, ok, err := templateMeta(tags[i])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", t.name, err)
+		}
+		metaName := jsName(t.name) + "Meta"
+		if !ok || slices.Contains(metaNames, metaName) {
+			// Translations of a template share its front matter.
+			continue
+		}
+		if slices.Contains(exportNames, metaName) {
+			return nil, fmt.Errorf("%s: front matter has the same JavaScript name as template %s", t.name, metaName)
+		}
+		metaNames = append(metaNames, metaName)
+		metaLiterals = append(metaLiterals, jsMetaLiteral(meta))
+		fmt.Fprintf(exports, ",%s:%s", metaName, metaLiterals[len(metaLiterals)-1])
+	}
+	switch {
+	case opts.format == "iife":
+		fmt.Fprintf(buf, "return {%s}\n", exports)
+	case opts.format == "cjs" && name == "":
+		fmt.Fprintf(buf, "module.exports={%s}\n", exports)
+	case opts.format == "cjs":
+		fmt.Fprintf(buf, "exports.%s={%s}\n", name, exports)
+	case name == "":
+		buf.WriteString("export {")
+		for i, exportName := range exportNames {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			fmt.Fprintf(buf, "t%d as %s", i, exportName)
+		}
+		buf.WriteString("}\n")
+		for i, metaName := range metaNames {
+			fmt.Fprintf(buf, "export const %s=%s\n", metaName, metaLiterals[i])
+		}
+	default:
+		fmt.Fprintf(buf, "export const %s={%s}\n", name, exports)
+	}
+	return writeJSModule(g, opts, escaper, name, buf.Bytes())
+}
+
+// compileJSRuntime generates a module that exports the prelude's helpers
+// for templates compiled with a runtime import path.
+func compileJSRuntime(opts *jsOptions) ([]byte, error) {
+	escaper := jsEscapers[opts.escaper]
+	if escaper == "" {
+		return nil, fmt.Errorf("unknown escaper %d", opts.escaper)
+	}
+	g := &jsGenerator{used: make(map[string]bool)}
+	var names []string
+	for _, h := range jsHelpers {
+		g.used[h.name] = true
+		if h.name != "match_html" {
+			names = append(names, h.name)
+		}
+	}
+	buf := new(bytes.Buffer)
+	switch opts.format {
+	case "", "esm":
+		fmt.Fprintf(buf, "export {%s}\n", strings.Join(names, ","))
+	case "cjs":
+		fmt.Fprintf(buf, "module.exports={%s}\n", strings.Join(names, ","))
+	default:
+		return nil, fmt.Errorf("cannot generate a runtime module in the %q format", opts.format)
+	}
+	runtimeOpts := *opts
+	runtimeOpts.runtime = ""
+	return writeJSModule(g, &runtimeOpts, escaper, "", buf.Bytes())
+}
+
+// writeJSModule returns a module with the given body,
+// preceded by the header comment and the prelude helpers
+// that the body uses (or an import of them).
+// For the "iife" format, the module is wrapped in a function
+// that assigns the value the body returns to the global variable name.
+func writeJSModule(g *jsGenerator, opts *jsOptions, escaper, name string, body []byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteString("// Code generated by mustache-codegen. DO NOT EDIT.\n")
+	if opts.format == "iife" {
+		// Keep the prelude and partials out of the global scope.
+		fmt.Fprintf(buf, "var %s=(()=>{\n", name)
+	}
+
+	used := make(map[string]bool)
+	var markUsed func(name string)
+	markUsed = func(name string) {
+		if !used[name] {
+			used[name] = true
+			for _, dep := range jsHelperDeps[name] {
+				markUsed(dep)
+			}
+		}
+	}
+	var imports []string
+	for _, h := range jsHelpers {
+		if g.used[h.name] {
+			markUsed(h.name)
+			imports = append(imports, h.name)
+		}
+	}
+	switch {
+	case opts.runtime == "":
+		for _, h := range jsHelpers {
+			if !used[h.name] {
+				continue
+			}
+			if h.name == "match_html" {
+				buf.WriteString(escaper)
+			} else {
+				buf.WriteString(h.decl)
+			}
+			buf.WriteString("\n")
+		}
+	case opts.format == "iife":
+		return nil, fmt.Errorf("cannot import a runtime in the %q format", opts.format)
+	case len(imports) == 0:
+	case opts.format == "cjs":
+		fmt.Fprintf(buf, "const {%s}=require('%s')\n", strings.Join(imports, ","), template.JSEscapeString(opts.runtime))
+	default:
+		fmt.Fprintf(buf, "import {%s} from '%s'\n", strings.Join(imports, ","), template.JSEscapeString(opts.runtime))
+	}
+	if g.usedFilters {
+		switch opts.format {
+		case "iife":
+			return nil, fmt.Errorf("cannot import filters in the %q format", opts.format)
+		case "cjs":
+			fmt.Fprintf(buf, "const filters=require('%s')\n", template.JSEscapeString(opts.filters.path))
+		default:
+			fmt.Fprintf(buf, "import * as filters from '%s'\n", template.JSEscapeString(opts.filters.path))
+		}
+	}
+
+	buf.Write(body)
+	if opts.format == "iife" {
+		buf.WriteString("})();\n")
+	}
+	if opts.pretty {
+		return formatJS(buf.Bytes()), nil
+	}
+	return buf.Bytes(), nil
+}
+
+// formatJS formats generated code for reading
+// by putting statements on separate lines and indenting blocks.
+// It relies on the limited syntax of generated code:
+// each line is formatted separately,
+// lines without braces and comments are left as they are,
+// and string literals are the only tokens that contain punctuation.
+func formatJS(js []byte) []byte {
+	buf := new(bytes.Buffer)
+	for _, line := range bytes.SplitAfter(js, []byte("\n")) {
+		code := bytes.TrimSuffix(line, []byte("\n"))
+		if !bytes.Contains(code, []byte("{")) || bytes.HasPrefix(code, []byte("//")) {
+			buf.Write(line)
+			continue
+		}
+		formatJSLine(buf, code)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+func formatJSLine(buf *bytes.Buffer, code []byte) {
+	// brackets is the stack of open brackets.
+	var brackets []byte
+	depth := 0
+	// newline is whether a line break goes before the next token.
+	newline := false
+	write := func(b []byte) {
+		if newline {
+			buf.WriteString("\n")
+			buf.WriteString(strings.Repeat("  ", depth))
+			newline = false
+		}
+		buf.Write(b)
+	}
+	for i := 0; i < len(code); i++ {
+		switch c := code[i]; c {
+		case '\'', '"':
+			j := i + 1
+			for ; j < len(code) && code[j] != c; j++ {
+				if code[j] == '\\' {
+					j++
+				}
+			}
+			write(code[i : j+1])
+			i = j
+		case '(', '[':
+			brackets = append(brackets, c)
+			write(code[i : i+1])
+		case ')', ']':
+			if len(brackets) > 0 {
+				brackets = brackets[:len(brackets)-1]
+			}
+			write(code[i : i+1])
+		case '{':
+			if i+1 < len(code) && code[i+1] == '}' {
+				write(code[i : i+2])
+				i++
+				continue
+			}
+			write(code[i : i+1])
+			brackets = append(brackets, c)
+			depth++
+			newline = true
+		case '}':
+			if len(brackets) > 0 {
+				brackets = brackets[:len(brackets)-1]
+				depth--
+			}
+			newline = true
+			write(code[i : i+1])
+			newline = i+1 < len(code) && !bytes.ContainsAny(code[i+1:i+2], ";,:)] ")
+		case ';':
+			if len(brackets) > 0 && brackets[len(brackets)-1] != '{' {
+				// A for statement's header.
+				write(code[i : i+1])
+				continue
+			}
+			if !newline {
+				// Otherwise, the statement is empty.
+				write(code[i : i+1])
+			}
+			newline = true
+		case ' ':
+			if !newline {
+				write(code[i : i+1])
+			}
+		default:
+			write(code[i : i+1])
+		}
+	}
+}
+
+// jsModule collects the partials used by the templates in a generated module.
+type jsModule struct {
+	partials []jsPartial
+	// namespaces maps the directory partials are loaded from
+	// (and the locale they are translated to, if any)
+	// to the partials loaded from it.
+	namespaces map[string]*jsNamespace
+	// minifyHTML is whether to minify the HTML in the templates and partials.
+	minifyHTML bool
+	// frontMatter is whether front matter is recognized at the start of templates and partials.
+	frontMatter bool
+	// filters is whether filter pipelines are recognized in variable tags.
+	filters bool
+	// i18n is whether {{#_i18n}} sections are parsed as messages.
+	i18n bool
+	// translations is whether any of the templates or partials
+	// has a [syntax.Translation] tag.
+	translations bool
+}
+
+type jsPartial struct {
+	tags []syntax.Tag
+	// funcNames maps the names of the partials the partial refers to
+	// to their generated function names.
+	funcNames map[string]string
+	// strict is whether the partial is used by a template with the STRICT pragma.
+	strict bool
+}
+
+// jsNamespace is the set of partials loaded from a directory.
+type jsNamespace struct {
+	// funcNames maps partial names to their generated function names.
+	funcNames map[string]string
+	// partials is the list of indices in jsModule.partials
+	// of the partials loaded from the directory.
+	partials []int
+}
 
-	var partials [][]tag
-	partialFuncNames := make(map[string]string)
+// add parses a template and loads the partials it refers to,
+// returning the template's tags, the function names of its partials,
+// and opts with the template's pragmas applied.
+// Partials with the same content that are loaded from the same dir
+// share a function.
+// If t.translation is not nil, the messages in the template and its partials are translated.
+func (m *jsModule) add(t jsTemplate, opts *jsOptions) ([]syntax.Tag, map[string]string, *jsOptions, error) {
+	prepare := func(partial string, source string) ([]syntax.Tag, error) {
+		tags, err := syntax.ParseOptions(source, &syntax.Options{Filters: m.filters, I18n: m.i18n, FrontMatter: m.frontMatter})
+		if err != nil {
+			return nil, err
+		}
+		// Pragmas only apply to the template itself,
+		// but are checked in partials too.
+		if _, err := syntax.Pragmas(tags); err != nil {
+			return nil, err
+		}
+		if t.translation != nil {
+			tags, err = t.translation.translate(t.name, partial, tags)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if m.minifyHTML {
+			if partial == "" {
+				tags = syntax.MinifyHTML(tags)
+			} else {
+				tags = syntax.MinifyHTMLPartial(tags)
+			}
+		}
+		return tags, nil
+	}
+	tags, err := prepare("", t.source)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	opts, err = jsPragmaOptions(tags, opts)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	nsKey := t.dir
+	if t.translation != nil {
+		nsKey += "\x00" + t.translation.locale
+	}
+	if opts.strict {
+		nsKey += "\x00strict"
+	}
+	ns := m.namespaces[nsKey]
+	if ns == nil {
+		ns = &jsNamespace{funcNames: make(map[string]string)}
+		m.namespaces[nsKey] = ns
+	}
 
-	var gatherPartials func(tags []tag) error
-	gatherPartials = func(tags []tag) error {
-		for t := range walkTags(tags) {
-			if t.tt == partial || t.tt == parent {
-				if partialFuncNames[t.s] != "" {
+	var gatherPartials func(tags []syntax.Tag) error
+	gatherPartials = func(tags []syntax.Tag) error {
+		for tag := range syntax.WalkTags(tags) {
+			if tag.Type == syntax.Partial || tag.Type == syntax.Parent {
+				if ns.funcNames[tag.S] != "" {
 					continue
 				}
-				source, err := load(t.s)
+				source, err := t.load(tag.S)
 				if err != nil {
 					return err
 				}
-				partialTags, err := parse(source)
+				partialTags, err := prepare(tag.S, source)
 				if err != nil {
-					return fmt.Errorf("partial %s: %v", t.s, err)
+					return fmt.Errorf("partial %s: %v", tag.S, err)
 				}
 
-				i := slices.IndexFunc(partials, func(p []tag) bool {
-					return slices.EqualFunc(partialTags, p, tagsEqual)
+				i := slices.IndexFunc(ns.partials, func(i int) bool {
+					return slices.EqualFunc(partialTags, m.partials[i].tags, syntax.TagsEqual)
 				})
 				if i == -1 {
-					partials = append(partials, partialTags)
-					i = len(partials) - 1
+					m.partials = append(m.partials, jsPartial{tags: partialTags, funcNames: ns.funcNames, strict: opts.strict})
+					ns.partials = append(ns.partials, len(m.partials)-1)
+					i = len(m.partials) - 1
+				} else {
+					i = ns.partials[i]
 				}
-				partialFuncNames[t.s] = fmt.Sprintf("p%d", i)
+				ns.funcNames[tag.S] = fmt.Sprintf("p%d", i)
 				if err := gatherPartials(partialTags); err != nil {
 					return err
 				}
@@ -56,56 +716,183 @@ func compileJS(source string, load func(name string) (string, error)) ([]byte, e
 		return nil
 	}
 	if err := gatherPartials(tags); err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
+	for tag := range syntax.WalkTags(tags) {
+		m.translations = m.translations || tag.Type == syntax.Translation
+	}
+	for _, p := range m.partials {
+		for tag := range syntax.WalkTags(p.tags) {
+			m.translations = m.translations || tag.Type == syntax.Translation
+		}
+	}
+	return tags, ns.funcNames, opts, nil
+}
 
-	buf := new(bytes.Buffer)
-	buf.WriteString("// Code generated by mustache-codegen. DO NOT EDIT.\n")
-	buf.WriteString(prelude)
-
-	for i, partialTags := range partials {
-		fmt.Fprintf(buf, "function p%d", i)
-		buf.WriteString(`(n,s,b){let x=''`)
-		if err := compileTagListJS(buf, partialTags, partialFuncNames, true, true); err != nil {
-			return nil, err
+// writePartials writes the partials' functions.
+func (m *jsModule) writePartials(buf *bytes.Buffer, g *jsGenerator) error {
+	for i, p := range m.partials {
+		g.partialFuncNames = p.funcNames
+		g.strict = p.strict
+		if g.stream {
+			fmt.Fprintf(buf, "async function* p%d(n,s,b){", i)
+		} else {
+			fmt.Fprintf(buf, "function p%d(n,s,b){let x=''", i)
+		}
+		if err := compileTagListJS(buf, p.tags, g, true, true); err != nil {
+			return err
+		}
+		if g.stream {
+			buf.WriteString("}\n")
+		} else {
+			buf.WriteString(";return x}\n")
 		}
-		buf.WriteString(`;return x}` + "\n")
 	}
+	return nil
+}
 
-	buf.WriteString(`export default function(data){let s=[data],x=''`)
-	if err := compileTagListJS(buf, tags, partialFuncNames, false, false); err != nil {
-		return nil, err
+// jsGenerator holds the state shared by the functions
+// that generate the JavaScript code for a template.
+type jsGenerator struct {
+	// partialFuncNames maps partial names to their generated function names.
+	partialFuncNames map[string]string
+	// stream is whether to generate async generator functions
+	// instead of functions that return a string.
+	stream bool
+	// used is the set of prelude helpers that the generated code uses.
+	used map[string]bool
+	// translations is whether template functions take a translator.
+	translations bool
+	// filters is the module of filter functions, if any.
+	filters *filterPackage
+	// usedFilters is whether the generated code applies filters.
+	usedFilters bool
+	// strict is whether variables that cannot be found are errors.
+	strict bool
+}
+
+// use records that the generated code uses the prelude helper name
+// and returns name.
+func (g *jsGenerator) use(name string) string {
+	if g.used == nil {
+		g.used = make(map[string]bool)
 	}
-	buf.WriteString(`;return x}`)
-	return buf.Bytes(), nil
+	g.used[name] = true
+	return name
+}
+
+// write returns the beginning of a statement that appends the value
+// of the expression that follows it to the output.
+// The expression must be followed by [jsGenerator.endWrite].
+func (g *jsGenerator) write() string {
+	if g.stream {
+		return ";yield ''+("
+	}
+	return ";x+="
+}
+
+func (g *jsGenerator) endWrite() string {
+	if g.stream {
+		return ")"
+	}
+	return ""
+}
+
+// call returns the beginning of a statement that appends the output
+// of a call to a generated function to the output.
+func (g *jsGenerator) call() string {
+	if g.stream {
+		return ";yield* "
+	}
+	return ";x+="
+}
+
+// lookup returns the beginning of an expression that evaluates a name,
+// which must be followed by [jsGenerator.endLookup].
+// When streaming, Promise-valued data is awaited.
+func (g *jsGenerator) lookup() string {
+	if g.stream {
+		return "(await "
+	}
+	return ""
+}
+
+func (g *jsGenerator) endLookup() string {
+	if g.stream {
+		return ")"
+	}
+	return ""
+}
+
+// writeTemplateFunc writes a template's function.
+// If named is true, the function declaration has the given name,
+// otherwise it is an anonymous function expression.
+func (g *jsGenerator) writeTemplateFunc(buf *bytes.Buffer, named bool, name string, tags []syntax.Tag) error {
+	if g.stream {
+		buf.WriteString("async function*")
+	} else {
+		buf.WriteString("function")
+	}
+	if named {
+		buf.WriteString(" ")
+		buf.WriteString(name)
+	}
+	if g.translations {
+		buf.WriteString(`(data,tr){let s=[data]`)
+	} else {
+		buf.WriteString(`(data){let s=[data]`)
+	}
+	if !g.stream {
+		buf.WriteString(`,x=''`)
+	}
+	if g.translations {
+		buf.WriteString(`;s.t=tr`)
+	}
+	if err := compileTagListJS(buf, tags, g, false, false); err != nil {
+		return err
+	}
+	if g.stream {
+		buf.WriteString(`}`)
+	} else {
+		buf.WriteString(`;return x}`)
+	}
+	return nil
 }
 
-func compileTagListJS(buf *bytes.Buffer, tags []tag, partialFuncNames map[string]string, blocks, indent bool) error {
+func compileTagListJS(buf *bytes.Buffer, tags []syntax.Tag, g *jsGenerator, blocks, indent bool) error {
 	for i := 0; i < len(tags); i++ {
 		t := tags[i]
-		if !indent && t.tt == literal {
+		if !indent && t.Type == syntax.Literal {
 			var n int
-			t, n = condenseLiteralsWithoutIndentation(tags[i:])
+			t, n = syntax.CondenseLiteralsWithoutIndentation(tags[i:])
 			i += n - 1
 		}
-		if err := compileTagJS(buf, t, partialFuncNames, blocks, indent); err != nil {
+		if err := compileTagJS(buf, t, g, blocks, indent); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func compileTagJS(buf *bytes.Buffer, t tag, partialFuncNames map[string]string, blocks, indent bool) error {
+func compileTagJS(buf *bytes.Buffer, t syntax.Tag, g *jsGenerator, blocks, indent bool) error {
 	// prelude helpers:
 	// esc(s): escape value
 	// f(x): is falsey
 	// arr(x): is array
+	// it(x): is iterable (and not a string)
+	// each(c,g): call g for each value c renders a section with
+	// aeach(c,g): delegate to the async generator g for each value c renders a section with
+	// has(c,k): c or its prototypes (other than Object.prototype) have property k
+	// call(c,v): call v with c as this if v is a function without parameters
 	// look(s,k): lookup k in stack s
+	// prop(x,k): lookup k in x
+	// i18n(s,m): render the translation of message m
+	// ai18n(s,m): render the translation of message m, awaiting its variables
 
 	// guide to variables:
-	// x: output string
+	// x: output string (unless streaming)
 	// d: data argument (don't use)
-	// s: context stack
+	// s: context stack (s.t is the translator function)
 	// c: section context
 	// g: anonymous block function
 	// e: anonymous block function context
@@ -113,92 +900,150 @@ func compileTagJS(buf *bytes.Buffer, t tag, partialFuncNames map[string]string,
 	// bb: block
 	// n: indent
 
-	switch t.tt {
-	case literal:
-		buf.WriteString(`;x+='`)
-		template.JSEscape(buf, []byte(t.s))
+	switch t.Type {
+	case syntax.Literal:
+		switch {
+		case !g.stream:
+			buf.WriteString(`;x+='`)
+		case t.S != "":
+			buf.WriteString(`;yield '`)
+		default:
+			return nil
+		}
+		template.JSEscape(buf, []byte(t.S))
 		buf.WriteString(`'`)
-	case indentPoint:
-		if indent {
+	case syntax.IndentPoint:
+		switch {
+		case !indent:
+		case g.stream:
+			buf.WriteString(";if(n)yield n")
+		default:
 			buf.WriteString(";x+=n")
 		}
-	case variable:
-		buf.WriteString(`;x+=esc(`)
-		compileNamePathJS(buf, t.s)
+	case syntax.Variable:
+		buf.WriteString(g.write())
+		buf.WriteString(g.use("esc"))
+		buf.WriteString(`(`)
+		if err := compileValueJS(buf, t, g); err != nil {
+			return err
+		}
 		buf.WriteString("??'')")
-	case rawVariable:
-		buf.WriteString(`;x+=`)
-		compileNamePathJS(buf, t.s)
+		buf.WriteString(g.endWrite())
+	case syntax.RawVariable:
+		buf.WriteString(g.write())
+		if err := compileValueJS(buf, t, g); err != nil {
+			return err
+		}
 		buf.WriteString("??''")
-	case section:
+		buf.WriteString(g.endWrite())
+	case syntax.Pragma:
+		// Pragmas have been applied to the compile options.
+	case syntax.FrontMatter:
+		// Front matter is written as metadata, not rendered.
+	case syntax.Translation:
+		buf.WriteString(g.write())
+		if g.stream {
+			buf.WriteString("await ")
+			buf.WriteString(g.use("ai18n"))
+		} else {
+			buf.WriteString(g.use("i18n"))
+		}
+		buf.WriteString("(s,'")
+		template.JSEscape(buf, []byte(t.S))
+		buf.WriteString("')")
+		buf.WriteString(g.endWrite())
+	case syntax.Section:
 		buf.WriteString(`;{let c=`)
-		compileNamePathJS(buf, t.s)
-		buf.WriteString(`;if(!f(c)){let g=(e)=>{s.push(e)`)
-		if err := compileTagListJS(buf, t.body, partialFuncNames, blocks, indent); err != nil {
+		compileNamePathJS(buf, t.S, g)
+		g.use("f")
+		if g.stream {
+			buf.WriteString(`;if(!f(c)){let g=async function*(e){s.push(e)`)
+		} else {
+			buf.WriteString(`;if(!f(c)){let g=(e)=>{s.push(e)`)
+		}
+		if err := compileTagListJS(buf, t.Body, g, blocks, indent); err != nil {
 			return err
 		}
-		buf.WriteString(`;s.pop(e)};arr(c)?c.forEach(g):g(c)}}`)
-	case invertedSection:
-		buf.WriteString(`;if(f(`)
-		compileNamePathJS(buf, t.s)
+		if g.stream {
+			g.use("aeach")
+			buf.WriteString(`;s.pop(e)};yield* aeach(c,g)}}`)
+		} else {
+			g.use("each")
+			buf.WriteString(`;s.pop(e)};each(c,g)}}`)
+		}
+	case syntax.InvertedSection:
+		buf.WriteString(`;if(`)
+		buf.WriteString(g.use("f"))
+		buf.WriteString(`(`)
+		compileNamePathJS(buf, t.S, g)
 		buf.WriteString(`)){`)
-		if err := compileTagListJS(buf, t.body, partialFuncNames, blocks, indent); err != nil {
+		if err := compileTagListJS(buf, t.Body, g, blocks, indent); err != nil {
 			return err
 		}
 		buf.WriteString(`}`)
-	case partial:
-		buf.WriteString(`;x+=`)
-		buf.WriteString(partialFuncNames[t.s])
+	case syntax.Partial:
+		buf.WriteString(g.call())
+		buf.WriteString(g.partialFuncNames[t.S])
 		buf.WriteString("(")
-		jsIncreaseIndent(buf, indent, t.indent)
+		jsIncreaseIndent(buf, indent, t.Indent)
 		buf.WriteString(`,s,{})`)
-	case block:
+	case syntax.Block:
 		if blocks {
 			buf.WriteString(`;{const bb=b`)
-			if isJSIdentifier(t.s) {
+			if isJSIdentifier(t.S) {
 				buf.WriteString(`.`)
-				buf.WriteString(t.s)
+				buf.WriteString(t.S)
 			} else {
 				buf.WriteString(`['`)
-				template.JSEscape(buf, []byte(t.s))
+				template.JSEscape(buf, []byte(t.S))
 				buf.WriteString(`']`)
 			}
-			buf.WriteString(`;if(bb!==undefined)x+=bb(`)
-			jsIncreaseIndent(buf, t.indentArgument && indent, t.indent)
+			buf.WriteString(`;if(bb!==undefined)`)
+			buf.WriteString(strings.TrimPrefix(g.call(), ";"))
+			buf.WriteString(`bb(`)
+			jsIncreaseIndent(buf, t.IndentArgument && indent, t.Indent)
 			buf.WriteString(`,s);else{`)
 		}
-		if err := compileTagListJS(buf, t.body, partialFuncNames, blocks, indent); err != nil {
+		if err := compileTagListJS(buf, t.Body, g, blocks, indent); err != nil {
 			return err
 		}
 		if blocks {
 			buf.WriteString(`}}`)
 		}
-	case parent:
-		buf.WriteString(`;x+=`)
-		buf.WriteString(partialFuncNames[t.s])
+	case syntax.Parent:
+		buf.WriteString(g.call())
+		buf.WriteString(g.partialFuncNames[t.S])
 		buf.WriteString("(")
-		jsIncreaseIndent(buf, indent, t.indent)
+		jsIncreaseIndent(buf, indent, t.Indent)
 		buf.WriteString(`,s,{`)
 		first := true
-		for _, blockTag := range t.body {
-			if blockTag.tt != block {
+		for _, blockTag := range t.Body {
+			if blockTag.Type != syntax.Block {
 				continue
 			}
 			if !first {
 				buf.WriteString(`,`)
 			}
-			if isJSIdentifier(blockTag.s) {
-				buf.WriteString(blockTag.s)
+			if isJSIdentifier(blockTag.S) {
+				buf.WriteString(blockTag.S)
 			} else {
 				buf.WriteString(`'`)
-				template.JSEscape(buf, []byte(blockTag.s))
+				template.JSEscape(buf, []byte(blockTag.S))
 				buf.WriteString(`'`)
 			}
-			buf.WriteString(`:(n,s)=>{let x=''`)
-			if err := compileTagListJS(buf, blockTag.body, partialFuncNames, blocks, true); err != nil {
+			if g.stream {
+				buf.WriteString(`:async function*(n,s){`)
+			} else {
+				buf.WriteString(`:(n,s)=>{let x=''`)
+			}
+			if err := compileTagListJS(buf, blockTag.Body, g, blocks, true); err != nil {
 				return err
 			}
-			buf.WriteString(`;return x}`)
+			if g.stream {
+				buf.WriteString(`}`)
+			} else {
+				buf.WriteString(`;return x}`)
+			}
 			first = false
 		}
 		if blocks {
@@ -209,30 +1054,71 @@ func compileTagJS(buf *bytes.Buffer, t tag, partialFuncNames map[string]string,
 		}
 		buf.WriteString(`})`)
 	default:
-		return fmt.Errorf("unhandled tag %d", t.tt)
+		return fmt.Errorf("unhandled tag %d", t.Type)
 	}
 	return nil
 }
 
-func compileNamePathJS(w *bytes.Buffer, name string) {
+// compileValueJS writes the expression for the value of a variable tag
+// with its filters applied.
+// In strict mode, the value is checked before it is filtered.
+// When streaming, the filters' Promise results are awaited.
+func compileValueJS(w *bytes.Buffer, t syntax.Tag, g *jsGenerator) error {
+	funcNames := make([]string, len(t.Filters))
+	for i, filter := range t.Filters {
+		var err error
+		funcNames[i], err = g.filters.filterFuncName(filter, true)
+		if err != nil {
+			return fmt.Errorf("%d: %v", t.Line, err)
+		}
+		g.usedFilters = true
+	}
+	for _, name := range slices.Backward(funcNames) {
+		w.WriteString(g.lookup())
+		w.WriteString("filters.")
+		w.WriteString(name)
+		w.WriteString("(")
+	}
+	if g.strict && t.S != "." {
+		w.WriteString(g.use("must"))
+		w.WriteString("(")
+		compileNamePathJS(w, t.S, g)
+		w.WriteString(",'")
+		template.JSEscape(w, []byte(t.S))
+		w.WriteString("')")
+	} else {
+		compileNamePathJS(w, t.S, g)
+	}
+	for range funcNames {
+		w.WriteString(")")
+		w.WriteString(g.endLookup())
+	}
+	return nil
+}
+
+func compileNamePathJS(w *bytes.Buffer, name string, g *jsGenerator) {
 	if name == "." {
 		w.WriteString("s.at(-1)")
 		return
 	}
 
 	parts := strings.Split(name, ".")
-	w.WriteString("look(s,'")
+	for range parts[1:] {
+		w.WriteString(g.lookup())
+		w.WriteString(g.use("prop"))
+		w.WriteString("(")
+	}
+	w.WriteString(g.lookup())
+	w.WriteString(g.use("look"))
+	w.WriteString("(s,'")
 	template.JSEscape(w, []byte(parts[0]))
 	w.WriteString("')")
+	w.WriteString(g.endLookup())
 	for _, part := range parts[1:] {
-		w.WriteString("?.")
-		if isJSIdentifier(part) {
-			w.WriteString(part)
-		} else {
-			w.WriteString(`['`)
-			template.JSEscape(w, []byte(part))
-			w.WriteString(`']`)
-		}
+		w.WriteString(",'")
+		template.JSEscape(w, []byte(part))
+		w.WriteString("')")
+		w.WriteString(g.endLookup())
 	}
 }
 
@@ -8,6 +8,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -27,30 +29,18 @@ func TestCompileJS(t *testing.T) {
 
 			for _, test := range suite {
 				t.Run(test.Name, func(t *testing.T) {
-					js, err := compileJS(test.Template, func(name string) (string, error) {
-						return test.Partials[name], nil
-					})
-					if err != nil {
-						t.Fatal("compile:", err)
-					}
-					const templateFilename = "template.mjs"
-					templatePath := filepath.Join(t.TempDir(), templateFilename)
-					if err := os.WriteFile(templatePath, js, 0o666); err != nil {
-						t.Fatal(err)
-					}
-					generatedCode := bytes.TrimPrefix(js, []byte(prelude))
-
-					script := `import t from './` + templateFilename + `'; process.stdout.write(t(` + string(test.Data) + `))`
-					c := exec.Command(nodePath, "--input-type=module", "-e", script)
-					c.Dir = filepath.Dir(templatePath)
-					stdout := new(bytes.Buffer)
-					c.Stdout = stdout
-					c.Stderr = os.Stderr
-					if err := c.Run(); err != nil {
-						t.Fatalf("error: %s\ngenerated code:\n%s", err, generatedCode)
-					}
-					if got := stdout.String(); got != test.Expected {
-						t.Errorf("output:\n%q\nexpected:\n%q\ngenerated code:\n%s", got, test.Expected, generatedCode)
+					for _, mode := range jsModes {
+						t.Run(mode.name, func(t *testing.T) {
+							js, err := compileJS("template", test.Template, func(name string) (string, error) {
+								return test.Partials[name], nil
+							}, &jsOptions{stream: mode.stream, pretty: mode.pretty})
+							if err != nil {
+								t.Fatal("compile:", err)
+							}
+							if got := runJS(t, nodePath, js, string(test.Data), mode.stream); got != test.Expected {
+								t.Errorf("output:\n%q\nexpected:\n%q\ngenerated code:\n%s", got, test.Expected, generatedJS(js))
+							}
+						})
 					}
 				})
 			}
@@ -58,6 +48,58 @@ func TestCompileJS(t *testing.T) {
 	}
 }
 
+// jsModes is the list of -js-mode values to test,
+// along with formatted output for -js-pretty.
+var jsModes = []struct {
+	name   string
+	stream bool
+	pretty bool
+}{
+	{"string", false, false},
+	{"stream", true, false},
+	{"pretty", false, true},
+}
+
+// runJS runs the compiled template js with data given as a JavaScript expression
+// and returns its output.
+// If stream is true, the output is the concatenation of the chunks
+// yielded by the template.
+func runJS(t *testing.T, nodePath string, js []byte, data string, stream bool) string {
+	t.Helper()
+	const templateFilename = "template.mjs"
+	templatePath := filepath.Join(t.TempDir(), templateFilename)
+	if err := os.WriteFile(templatePath, js, 0o666); err != nil {
+		t.Fatal(err)
+	}
+
+	script := `import t from './` + templateFilename + `'; process.stdout.write(t(` + data + `))`
+	if stream {
+		script = `import t from './` + templateFilename + `'; let out = ''; ` +
+			`for await (const chunk of t(` + data + `)) { ` +
+			`if (typeof chunk !== 'string') throw new Error('yielded ' + typeof chunk); out += chunk } ` +
+			`process.stdout.write(out)`
+	}
+	c := exec.Command(nodePath, "--input-type=module", "-e", script)
+	c.Dir = filepath.Dir(templatePath)
+	stdout := new(bytes.Buffer)
+	c.Stdout = stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		t.Fatalf("error: %s\ngenerated code:\n%s", err, generatedJS(js))
+	}
+	return stdout.String()
+}
+
+// generatedJS returns the compiled template js
+// without its header comment and prelude.
+// The prelude is only removed if js is not formatted.
+func generatedJS(js []byte) []byte {
+	for bytes.HasPrefix(js, []byte("//")) || bytes.HasPrefix(js, []byte("const ")) {
+		_, js, _ = bytes.Cut(js, []byte("\n"))
+	}
+	return js
+}
+
 // FuzzCompileJSDeterminism verifies that JavaScript code generation
 // yields the same code each time it is called with the same template.
 func FuzzCompileJSDeterminism(f *testing.F) {
@@ -73,11 +115,11 @@ func FuzzCompileJSDeterminism(f *testing.F) {
 
 	f.Fuzz(func(t *testing.T, s string) {
 		load := func(name string) (string, error) { return "", nil }
-		got1, err := compileJS(s, load)
+		got1, err := compileJS("template", s, load, new(jsOptions))
 		if err != nil {
 			t.Skip("Invalid template:", err)
 		}
-		got2, err := compileJS(s, load)
+		got2, err := compileJS("template", s, load, new(jsOptions))
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -86,3 +128,665 @@ func FuzzCompileJSDeterminism(f *testing.F) {
 		}
 	})
 }
+
+// TestCompileJSValues verifies how generated JavaScript code
+// handles values that cannot be represented in JSON:
+// iterables, Maps, class instances, and functions.
+func TestCompileJSValues(t *testing.T) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Cannot find node:", err)
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		data     string
+		want     string
+	}{
+		{
+			name:     "Set",
+			template: "{{#set}}({{.}}){{/set}}",
+			data:     "{set: new Set([1, 2, 3])}",
+			want:     "(1)(2)(3)",
+		},
+		{
+			name:     "EmptySet",
+			template: "{{#set}}({{.}}){{/set}}{{^set}}empty{{/set}}",
+			data:     "{set: new Set()}",
+			want:     "empty",
+		},
+		{
+			name:     "MapEntries",
+			template: "{{#map}}{{key}}={{value}};{{/map}}",
+			data:     "{map: new Map([['a', 1], ['b', 2]])}",
+			want:     "a=1;b=2;",
+		},
+		{
+			name:     "EmptyMap",
+			template: "{{^map}}empty{{/map}}",
+			data:     "{map: new Map()}",
+			want:     "empty",
+		},
+		{
+			name:     "MapContext",
+			template: "{{name}} {{obj.name}} {{#obj}}{{name}}{{/obj}}",
+			data:     "new Map([['name', 'a'], ['obj', {name: 'b'}]])",
+			want:     "a b b",
+		},
+		{
+			name:     "MapDottedName",
+			template: "{{obj.inner.name}}",
+			data:     "{obj: new Map([['inner', new Map([['name', 'x']])]])}",
+			want:     "x",
+		},
+		{
+			name:     "Generator",
+			template: "{{#gen}}({{.}}){{/gen}}",
+			data:     "{gen: (function*() { yield 'a'; yield 'b' })()}",
+			want:     "(a)(b)",
+		},
+		{
+			name:     "CustomIterable",
+			template: "{{#list}}({{.}}){{/list}}{{^empty}}empty{{/empty}}",
+			data:     "{list: {*[Symbol.iterator]() { yield 1; yield 2 }}, empty: {*[Symbol.iterator]() {}}}",
+			want:     "(1)(2)empty",
+		},
+		{
+			name:     "String",
+			template: "{{#str}}({{.}}){{/str}}",
+			data:     "{str: 'abc'}",
+			want:     "(abc)",
+		},
+		{
+			name:     "StringProperties",
+			template: "{{#str}}{{length}} {{toUpperCase}}{{/str}}",
+			data:     "{str: 'abc', toUpperCase: 'outer'}",
+			want:     "3 outer",
+		},
+		{
+			name:     "ClassGetter",
+			template: "{{#user}}{{name}} {{fullName}}{{/user}}",
+			data:     "{user: new (class { constructor() { this.name = 'Ada' } get fullName() { return this.name + ' Lovelace' } })()}",
+			want:     "Ada Ada Lovelace",
+		},
+		{
+			name:     "InheritedProperty",
+			template: "{{#child}}{{name}}{{/child}} {{child.name}}",
+			data:     "{name: 'outer', child: Object.create({name: 'inherited'})}",
+			want:     "inherited inherited",
+		},
+		{
+			name:     "ObjectPrototype",
+			template: "{{#obj}}{{toString}}{{/obj}}",
+			data:     "{obj: {}, toString: 'outer'}",
+			want:     "outer",
+		},
+		{
+			name:     "Method",
+			template: "{{#user}}{{greeting}}{{/user}} {{user.greeting}}",
+			data:     "{user: new (class { constructor() { this.name = 'Ada' } greeting() { return 'Hi, ' + this.name } })()}",
+			want:     "Hi, Ada Hi, Ada",
+		},
+		{
+			name:     "MethodSection",
+			template: "{{#user.items}}({{.}}){{/user.items}}{{^user.none}}none{{/user.none}}",
+			data:     "{user: {items() { return [1, 2] }, none() { return [] }}}",
+			want:     "(1)(2)none",
+		},
+		{
+			name:     "FunctionWithParameters",
+			template: "{{#f}}yes{{/f}}",
+			data:     "{f: (x) => false}",
+			want:     "yes",
+		},
+		{
+			name:     "NullContext",
+			template: "{{#list}}{{name}}{{/list}}",
+			data:     "{list: [null], name: 'outer'}",
+			want:     "outer",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, mode := range jsModes {
+				t.Run(mode.name, func(t *testing.T) {
+					js, err := compileJS("template", test.template, func(name string) (string, error) {
+						return "", nil
+					}, &jsOptions{stream: mode.stream, pretty: mode.pretty})
+					if err != nil {
+						t.Fatal("compile:", err)
+					}
+					if got := runJS(t, nodePath, js, test.data, mode.stream); got != test.want {
+						t.Errorf("output = %q; want %q", got, test.want)
+					}
+				})
+			}
+		})
+	}
+}
+
+// TestCompileJSStream verifies that templates generated with -js-mode=stream
+// await Promise-valued data and iterate over async iterables.
+func TestCompileJSStream(t *testing.T) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Cannot find node:", err)
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		data     string
+		want     string
+	}{
+		{
+			name:     "PromiseVariable",
+			template: "Hello, {{name}}!",
+			data:     "{name: Promise.resolve('World')}",
+			want:     "Hello, World!",
+		},
+		{
+			name:     "PromiseDottedName",
+			template: "{{user.name}}",
+			data:     "{user: Promise.resolve({name: Promise.resolve('Ada')})}",
+			want:     "Ada",
+		},
+		{
+			name:     "PromiseSection",
+			template: "{{#list}}({{.}}){{/list}}{{^empty}}empty{{/empty}}",
+			data:     "{list: Promise.resolve([1, Promise.resolve(2)]), empty: Promise.resolve([])}",
+			want:     "(1)(2)empty",
+		},
+		{
+			name:     "AsyncMethod",
+			template: "{{#user}}{{greeting}}{{/user}}",
+			data:     "{user: {name: 'Ada', async greeting() { return 'Hi, ' + this.name }}}",
+			want:     "Hi, Ada",
+		},
+		{
+			name:     "AsyncIterable",
+			template: "{{#gen}}({{.}}){{/gen}}",
+			data:     "{gen: (async function*() { yield 'a'; yield 'b' })()}",
+			want:     "(a)(b)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			js, err := compileJS("template", test.template, func(name string) (string, error) {
+				return "", nil
+			}, &jsOptions{stream: true})
+			if err != nil {
+				t.Fatal("compile:", err)
+			}
+			if got := runJS(t, nodePath, js, test.data, true); got != test.want {
+				t.Errorf("output = %q; want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCompileJSFormats(t *testing.T) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Cannot find node:", err)
+	}
+	const source = "Hello, {{>subject}}!"
+	load := func(name string) (string, error) { return "{{subject}}", nil }
+	const data = "{subject: 'World'}"
+
+	tests := []struct {
+		name     string
+		opts     jsOptions
+		filename string
+		// script is a CommonJS script that writes the output of the template
+		// loaded from filename.
+		script string
+		want   string
+	}{
+		{
+			name:     "ESMNamed",
+			opts:     jsOptions{format: "esm", name: "greet"},
+			filename: "template.mjs",
+			script:   `import('./template.mjs').then(m => process.stdout.write(Object.keys(m) + ' ' + m.greet(` + data + `)))`,
+			want:     "greet Hello, World!",
+		},
+		{
+			name:     "CommonJS",
+			opts:     jsOptions{format: "cjs"},
+			filename: "template.cjs",
+			script:   `process.stdout.write(require('./template.cjs')(` + data + `))`,
+			want:     "Hello, World!",
+		},
+		{
+			name:     "CommonJSNamed",
+			opts:     jsOptions{format: "cjs", name: "greet"},
+			filename: "template.cjs",
+			script:   `const m = require('./template.cjs'); process.stdout.write(Object.keys(m) + ' ' + m.greet(` + data + `))`,
+			want:     "greet Hello, World!",
+		},
+		{
+			name:     "IIFE",
+			opts:     jsOptions{format: "iife"},
+			filename: "template.js",
+			script: `const ctx = {}; ` +
+				`require('vm').runInNewContext(require('fs').readFileSync('template.js', 'utf8'), ctx); ` +
+				`process.stdout.write(Object.keys(ctx) + ' ' + ctx.myTemplate(` + data + `))`,
+			want: "myTemplate Hello, World!",
+		},
+		{
+			name:     "IIFENamed",
+			opts:     jsOptions{format: "iife", name: "greet"},
+			filename: "template.js",
+			script: `const ctx = {}; ` +
+				`require('vm').runInNewContext(require('fs').readFileSync('template.js', 'utf8'), ctx); ` +
+				`process.stdout.write(Object.keys(ctx) + ' ' + ctx.greet(` + data + `))`,
+			want: "greet Hello, World!",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			js, err := compileJS("my-template", source, load, &test.opts)
+			if err != nil {
+				t.Fatal("compile:", err)
+			}
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, test.filename), js, 0o666); err != nil {
+				t.Fatal(err)
+			}
+			c := exec.Command(nodePath, "-e", test.script)
+			c.Dir = dir
+			stdout := new(bytes.Buffer)
+			c.Stdout = stdout
+			c.Stderr = os.Stderr
+			if err := c.Run(); err != nil {
+				t.Fatalf("error: %s\ngenerated code:\n%s", err, js)
+			}
+			if got := stdout.String(); got != test.want {
+				t.Errorf("output = %q; want %q", got, test.want)
+			}
+		})
+	}
+
+	badOpts := []jsOptions{
+		{format: "umd"},
+		{format: "esm", name: "my-template"},
+		{format: "esm", name: "class"},
+		{format: "esm", name: "esc"},
+		{format: "esm", name: "p0"},
+		{format: "iife", name: "var"},
+	}
+	for _, opts := range badOpts {
+		if _, err := compileJS("foo", source, load, &opts); err == nil {
+			t.Errorf("compileJS(..., %+v) did not return an error", opts)
+		}
+	}
+	if js, err := compileJS("string", source, load, &jsOptions{format: "iife"}); err != nil {
+		t.Error(err)
+	} else if !bytes.Contains(js, []byte("var string=")) {
+		t.Errorf("iife for string.mustache does not declare string:\n%s", js)
+	}
+	// Names used by generated code are only a problem in module scope.
+	if _, err := compileJS("foo", source, load, &jsOptions{format: "cjs", name: "esc"}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestJSName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"foo_bar", "fooBar"},
+		{"404-page", "template404Page"},
+		{"len", "len"},
+		{"string", "string"},
+		{"func", "func"},
+		{"", "template"},
+	}
+	for _, test := range tests {
+		if got := jsName(test.name); got != test.want {
+			t.Errorf("jsName(%q) = %q; want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestCompileJSBundle(t *testing.T) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Cannot find node:", err)
+	}
+	partials := map[string]map[string]string{
+		"a": {"subject": "{{subject}}", "punctuation": "!"},
+		"b": {"subject": "nobody"},
+	}
+	loader := func(dir string) func(name string) (string, error) {
+		return func(name string) (string, error) { return partials[dir][name], nil }
+	}
+	templates := []jsTemplate{
+		{name: "hello", source: "Hello, {{>subject}}{{>punctuation}}", dir: "a", load: loader("a")},
+		{name: "good_bye", source: "Goodbye, {{>subject}}{{>punctuation}}", dir: "a", load: loader("a")},
+		{name: "other", source: "Hello, {{>subject}}", dir: "b", load: loader("b")},
+	}
+	const data = "{subject: 'World'}"
+	const calls = `[m.hello(` + data + `), m.goodBye(` + data + `), m.other(` + data + `)].join(' ')`
+	const want = "Hello, World! Goodbye, World! Hello, nobody"
+
+	tests := []struct {
+		name     string
+		opts     jsOptions
+		filename string
+		// script is a CommonJS script that assigns the exported templates to m
+		// and writes the output of calls.
+		script string
+		want   string
+	}{
+		{
+			name:     "ESM",
+			opts:     jsOptions{format: "esm"},
+			filename: "templates.mjs",
+			script:   `import('./templates.mjs').then(m => process.stdout.write(Object.keys(m) + ' ' + ` + calls + `))`,
+			want:     "goodBye,hello,other " + want,
+		},
+		{
+			name:     "ESMNamed",
+			opts:     jsOptions{format: "esm", name: "views"},
+			filename: "templates.mjs",
+			script:   `import('./templates.mjs').then(({views: m}) => process.stdout.write(Object.keys(m) + ' ' + ` + calls + `))`,
+			want:     "hello,goodBye,other " + want,
+		},
+		{
+			name:     "CommonJS",
+			opts:     jsOptions{format: "cjs"},
+			filename: "templates.cjs",
+			script:   `const m = require('./templates.cjs'); process.stdout.write(Object.keys(m) + ' ' + ` + calls + `)`,
+			want:     "hello,goodBye,other " + want,
+		},
+		{
+			name:     "CommonJSNamed",
+			opts:     jsOptions{format: "cjs", name: "views"},
+			filename: "templates.cjs",
+			script:   `const m = require('./templates.cjs').views; process.stdout.write(Object.keys(m) + ' ' + ` + calls + `)`,
+			want:     "hello,goodBye,other " + want,
+		},
+		{
+			name:     "IIFE",
+			opts:     jsOptions{format: "iife"},
+			filename: "templates.js",
+			script: `const ctx = {}; ` +
+				`require('vm').runInNewContext(require('fs').readFileSync('templates.js', 'utf8'), ctx); ` +
+				`const m = ctx.templates; process.stdout.write(Object.keys(ctx) + ' ' + ` + calls + `)`,
+			want: "templates " + want,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			js, err := compileJSBundle(templates, &test.opts)
+			if err != nil {
+				t.Fatal("compile:", err)
+			}
+			if n := bytes.Count(js, []byte("\nconst esc=")); n != 1 {
+				t.Errorf("prelude appears %d times; want 1", n)
+			}
+			// "a" has subject and punctuation and "b" has its own subject.
+			if n := bytes.Count(js, []byte("\nfunction p")); n != 3 {
+				t.Errorf("%d partial functions; want 3\ngenerated code:\n%s", n, js)
+			}
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, test.filename), js, 0o666); err != nil {
+				t.Fatal(err)
+			}
+			c := exec.Command(nodePath, "-e", test.script)
+			c.Dir = dir
+			stdout := new(bytes.Buffer)
+			c.Stdout = stdout
+			c.Stderr = os.Stderr
+			if err := c.Run(); err != nil {
+				t.Fatalf("error: %s\ngenerated code:\n%s", err, js)
+			}
+			if got := stdout.String(); got != test.want {
+				t.Errorf("output = %q; want %q", got, test.want)
+			}
+		})
+	}
+
+	// Names that are predeclared in Go are fine in JavaScript.
+	js, err := compileJSBundle([]jsTemplate{{name: "string", source: "---\ntitle: A\n---\na"}, {name: "copy", source: "b"}}, &jsOptions{frontMatter: true})
+	if err != nil {
+		t.Fatal("compile:", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "templates.mjs"), js, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	c := exec.Command(nodePath, "-e", `import('./templates.mjs').then(m => process.stdout.write(Object.keys(m).sort() + ' ' + m.string() + m.copy()))`)
+	c.Dir = dir
+	c.Stderr = os.Stderr
+	out, err := c.Output()
+	if err != nil {
+		t.Fatalf("error: %s\ngenerated code:\n%s", err, js)
+	}
+	if got, want := string(out), "copy,string,stringMeta ab"; got != want {
+		t.Errorf("output = %q; want %q", got, want)
+	}
+
+	badTemplates := [][]jsTemplate{
+		{},
+		{{name: "foo-bar", source: "a"}, {name: "foo_bar", source: "b"}},
+		{{name: "class", source: "a"}},
+		{{name: "foo", source: "{{#a}}"}},
+	}
+	for _, templates := range badTemplates {
+		if _, err := compileJSBundle(templates, &jsOptions{}); err == nil {
+			t.Errorf("compileJSBundle(%+v, ...) did not return an error", templates)
+		}
+	}
+	for _, name := range []string{"t0", "p1", "esc"} {
+		if _, err := compileJSBundle(templates, &jsOptions{name: name}); err == nil {
+			t.Errorf("compileJSBundle(..., {name: %q}) did not return an error", name)
+		}
+	}
+}
+
+func TestCompileJSRuntime(t *testing.T) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Cannot find node:", err)
+	}
+	const source = "{{#items}}<{{name}}>{{/items}}"
+	load := func(name string) (string, error) { return "", nil }
+	const data = "{items: [{name: 'a'}, {name: '&'}]}"
+	const want = "<a><&amp;>"
+
+	tests := []struct {
+		name    string
+		opts    jsOptions
+		runtime string
+		script  string
+	}{
+		{
+			name:    "ESM",
+			opts:    jsOptions{runtime: "./runtime.mjs"},
+			runtime: "runtime.mjs",
+			script:  `import('./template.mjs').then(m => process.stdout.write(m.default(` + data + `)))`,
+		},
+		{
+			name:    "CommonJS",
+			opts:    jsOptions{format: "cjs", runtime: "./runtime.cjs"},
+			runtime: "runtime.cjs",
+			script:  `process.stdout.write(require('./template.cjs')(` + data + `))`,
+		},
+		{
+			name:    "Pretty",
+			opts:    jsOptions{runtime: "./runtime.mjs", pretty: true},
+			runtime: "runtime.mjs",
+			script:  `import('./template.mjs').then(m => process.stdout.write(m.default(` + data + `)))`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runtime, err := compileJSRuntime(&test.opts)
+			if err != nil {
+				t.Fatal("compile runtime:", err)
+			}
+			js, err := compileJS("template", source, load, &test.opts)
+			if err != nil {
+				t.Fatal("compile:", err)
+			}
+			if bytes.Contains(js, []byte("const esc")) {
+				t.Errorf("generated code includes the prelude:\n%s", js)
+			}
+			dir := t.TempDir()
+			templateFilename := "template" + filepath.Ext(test.runtime)
+			if err := os.WriteFile(filepath.Join(dir, templateFilename), js, 0o666); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, test.runtime), runtime, 0o666); err != nil {
+				t.Fatal(err)
+			}
+			c := exec.Command(nodePath, "-e", test.script)
+			c.Dir = dir
+			stdout := new(bytes.Buffer)
+			c.Stdout = stdout
+			c.Stderr = os.Stderr
+			if err := c.Run(); err != nil {
+				t.Fatalf("error: %s\ngenerated code:\n%s", err, js)
+			}
+			if got := stdout.String(); got != want {
+				t.Errorf("output = %q; want %q", got, want)
+			}
+		})
+	}
+
+	if _, err := compileJS("template", source, load, &jsOptions{format: "iife", runtime: "./runtime.js"}); err == nil {
+		t.Error("compileJS with an iife runtime import did not return an error")
+	}
+	if _, err := compileJSRuntime(&jsOptions{format: "iife"}); err == nil {
+		t.Error("compileJSRuntime(iife) did not return an error")
+	}
+}
+
+func TestCompileJSTreeShaking(t *testing.T) {
+	load := func(name string) (string, error) { return "", nil }
+	tests := []struct {
+		source string
+		want   []string
+	}{
+		{"Hello", nil},
+		{"{{{raw}}}", []string{"has", "call", "look"}},
+		{"{{a}}", []string{"esc", "match_html", "has", "call", "look"}},
+		{"{{^a}}x{{/a}}", []string{"has", "call", "look", "arr", "it", "f"}},
+	}
+	for _, test := range tests {
+		js, err := compileJS("template", test.source, load, new(jsOptions))
+		if err != nil {
+			t.Errorf("compileJS(%q): %v", test.source, err)
+			continue
+		}
+		var got []string
+		for _, h := range jsHelpers {
+			if bytes.Contains(js, []byte("\nconst "+h.name+"=")) || bytes.Contains(js, []byte("\nconst "+h.name+" =")) {
+				got = append(got, h.name)
+			}
+		}
+		if !slices.Equal(got, test.want) {
+			t.Errorf("compileJS(%q) declares %q; want %q", test.source, got, test.want)
+		}
+	}
+}
+
+// TestJSHelperDeps verifies that jsHelperDeps lists every helper
+// that each of the prelude's helpers refers to.
+func TestJSHelperDeps(t *testing.T) {
+	// declaredBy maps each name the prelude declares to its helper.
+	declaredBy := make(map[string]string)
+	for _, h := range jsHelpers {
+		for _, m := range jsPreludeNames.FindAllStringSubmatch(h.decl, -1) {
+			declaredBy[m[1]] = h.name
+		}
+	}
+	for _, h := range jsHelpers {
+		for name, helper := range declaredBy {
+			if helper == h.name {
+				continue
+			}
+			ref := regexp.MustCompile(`(?:^|[^.\w$])` + regexp.QuoteMeta(name) + `\b`)
+			if ref.MatchString(h.decl[strings.Index(h.decl, "=")+1:]) && !slices.Contains(jsHelperDeps[h.name], helper) {
+				t.Errorf("%s refers to %s, but jsHelperDeps[%q] does not include %q", h.name, name, h.name, helper)
+			}
+		}
+	}
+}
+
+func TestCompileJSTranslation(t *testing.T) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Cannot find node:", err)
+	}
+	const source = "{{#_i18n}}Hello, {{name}}!{{/_i18n}} {{>footer}}"
+	load := func(name string) (string, error) {
+		return "{{#user}}{{#_i18n}}{{&name}} has {{count}} messages{{/_i18n}}{{/user}}", nil
+	}
+	const data = "{name: '<World>', user: {name: '<b>Ada</b>', count: 3}}"
+	const translate = `{'Hello, {{name}}!': 'Bonjour, {{ name }} !', '{{&name}} has {{count}} messages': '{{count}} messages pour {{&name}}'}`
+
+	for _, mode := range jsModes {
+		t.Run(mode.name, func(t *testing.T) {
+			js, err := compileJS("template", source, load, &jsOptions{stream: mode.stream, pretty: mode.pretty, translator: true})
+			if err != nil {
+				t.Fatal("compile:", err)
+			}
+			want := "Hello, &lt;World&gt;! <b>Ada</b> has 3 messages"
+			if got := runJS(t, nodePath, js, data, mode.stream); got != want {
+				t.Errorf("untranslated output = %q; want %q", got, want)
+			}
+			want = "Bonjour, &lt;World&gt; ! 3 messages pour <b>Ada</b>"
+			if got := runJS(t, nodePath, js, data+", m => ("+translate+")[m] ?? m", mode.stream); got != want {
+				t.Errorf("translated output = %q; want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestCompileJSCatalogs(t *testing.T) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Cannot find node:", err)
+	}
+	const source = "{{#_i18n}}Hello, {{name}}!{{/_i18n}} {{>footer}}"
+	load := func(name string) (string, error) {
+		return "{{#_i18n}}Bye{{/_i18n}}", nil
+	}
+	translations := []*translation{
+		{locale: "fr", messages: map[string]string{"Hello, {{name}}!": "Bonjour, {{name}} !", "Bye": "Au revoir"}},
+		{locale: "pt-BR", messages: map[string]string{"Hello, {{name}}!": "Olá, {{name}}!"}, fallback: true},
+	}
+	js, err := compileJS("template", source, load, &jsOptions{translations: translations})
+	if err != nil {
+		t.Fatal("compile:", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "template.mjs"), js, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	const script = `import('./template.mjs').then(m => process.stdout.write(Object.keys(m) + ' ' + m.template_fr({name: '<b>'}) + ' ' + m.template_pt_BR({name: '<b>'})))`
+	c := exec.Command(nodePath, "-e", script)
+	c.Dir = dir
+	c.Stderr = os.Stderr
+	out, err := c.Output()
+	if err != nil {
+		t.Fatalf("error: %v\ngenerated code:\n%s", err, generatedJS(js))
+	}
+	const want = "template_fr,template_pt_BR Bonjour, &lt;b&gt; ! Au revoir Olá, &lt;b&gt;! Bye"
+	if got := string(out); got != want {
+		t.Errorf("output = %q; want %q", got, want)
+	}
+	if bytes.Contains(js, []byte("i18n")) {
+		t.Errorf("generated code translates messages at run time:\n%s", generatedJS(js))
+	}
+
+	translations[1].fallback = false
+	if _, err := compileJS("template", source, load, &jsOptions{translations: translations}); err == nil {
+		t.Error("compileJS did not return an error for a missing translation")
+	}
+}
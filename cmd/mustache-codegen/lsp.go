@@ -0,0 +1,580 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/kagisearch/mustache-codegen/internal/syntax"
+)
+
+// lspMain runs the lsp command, a Language Server Protocol server
+// for templates that communicates over stdin and stdout.
+func lspMain(args []string) {
+	fset := flag.FlagSet{Usage: func() {}}
+	dataType := fset.String("go-type", "", "`type` of the templates' data, as in *example.com/app/views.Page, for hover information")
+	if err := fset.Parse(args); err != nil || fset.NArg() > 0 {
+		fmt.Fprintf(fset.Output(), "usage: %s lsp [options]\n\n", programName)
+		fset.PrintDefaults()
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		os.Exit(64) // EX_USAGE
+	}
+	s := newLSPServer(*dataType)
+	if err := s.serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: lsp: %v\n", programName, err)
+		os.Exit(1)
+	}
+}
+
+// lspServer is the state of a Language Server Protocol session.
+type lspServer struct {
+	// dataType is the type of the templates' data
+	// for templates that do not declare a GO_TYPE pragma.
+	dataType string
+	// docs maps the URIs of the open documents to their text.
+	docs map[string]string
+	// types finds the Go types that hover information comes from.
+	types *goTypeLoader
+	w     io.Writer
+}
+
+func newLSPServer(dataType string) *lspServer {
+	return &lspServer{
+		dataType: dataType,
+		docs:     make(map[string]string),
+		types:    newGoTypeLoader(),
+	}
+}
+
+// lspMessage is a JSON-RPC request, notification, or response.
+type lspMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *lspError       `json:"error,omitempty"`
+}
+
+type lspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// JSON-RPC error codes.
+const (
+	lspParseError     = -32700
+	lspInvalidParams  = -32602
+	lspMethodNotFound = -32601
+)
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspLocation struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+// Diagnostic severities.
+const (
+	lspSeverityError   = 1
+	lspSeverityWarning = 2
+)
+
+type lspTextDocumentPositionParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Position lspPosition `json:"position"`
+}
+
+type lspCompletionItem struct {
+	Label  string `json:"label"`
+	Kind   int    `json:"kind"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// lspCompletionItemProperty is the kind of completion items for blocks.
+const lspCompletionItemProperty = 10
+
+type lspHover struct {
+	Contents struct {
+		Kind  string `json:"kind"`
+		Value string `json:"value"`
+	} `json:"contents"`
+	Range lspRange `json:"range"`
+}
+
+// lspMaxMessageSize is the size in bytes of the largest message the server reads.
+const lspMaxMessageSize = 64 << 20
+
+// serve handles the messages read from r until it reads an exit notification
+// or reaches the end of r.
+func (s *lspServer) serve(r io.Reader, w io.Writer) error {
+	s.w = w
+	tr := textproto.NewReader(bufio.NewReader(r))
+	for {
+		header, err := tr.ReadMIMEHeader()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		n, err := strconv.Atoi(header.Get("Content-Length"))
+		if err != nil || n < 0 || n > lspMaxMessageSize {
+			return fmt.Errorf("invalid Content-Length %q", header.Get("Content-Length"))
+		}
+		body := make([]byte, n)
+		if _, err := io.ReadFull(tr.R, body); err != nil {
+			return err
+		}
+		var msg lspMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			if err := s.reply(nil, nil, &lspError{Code: lspParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if msg.Method == "exit" {
+			return nil
+		}
+		result, rpcErr := s.handle(msg.Method, msg.Params)
+		if msg.ID == nil {
+			// Notifications have no response.
+			continue
+		}
+		if err := s.reply(msg.ID, result, rpcErr); err != nil {
+			return err
+		}
+	}
+}
+
+// handle returns the result of a request or notification.
+func (s *lspServer) handle(method string, params json.RawMessage) (any, *lspError) {
+	switch method {
+	case "initialize":
+		return map[string]any{
+			"capabilities": map[string]any{
+				// Documents are synchronized by sending their full text.
+				"textDocumentSync":   1,
+				"definitionProvider": true,
+				"hoverProvider":      true,
+				"completionProvider": map[string]any{"triggerCharacters": []string{"$"}},
+			},
+			"serverInfo": map[string]any{"name": programName},
+		}, nil
+	case "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		var p struct {
+			TextDocument struct {
+				URI  string `json:"uri"`
+				Text string `json:"text"`
+			} `json:"textDocument"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &lspError{Code: lspInvalidParams, Message: err.Error()}
+		}
+		s.docs[p.TextDocument.URI] = p.TextDocument.Text
+		return nil, s.publishDiagnostics(p.TextDocument.URI)
+	case "textDocument/didChange":
+		var p struct {
+			TextDocument struct {
+				URI string `json:"uri"`
+			} `json:"textDocument"`
+			ContentChanges []struct {
+				Text string `json:"text"`
+			} `json:"contentChanges"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &lspError{Code: lspInvalidParams, Message: err.Error()}
+		}
+		if len(p.ContentChanges) > 0 {
+			s.docs[p.TextDocument.URI] = p.ContentChanges[len(p.ContentChanges)-1].Text
+		}
+		return nil, s.publishDiagnostics(p.TextDocument.URI)
+	case "textDocument/didClose":
+		var p struct {
+			TextDocument struct {
+				URI string `json:"uri"`
+			} `json:"textDocument"`
+		}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &lspError{Code: lspInvalidParams, Message: err.Error()}
+		}
+		delete(s.docs, p.TextDocument.URI)
+		return nil, s.notify("textDocument/publishDiagnostics", map[string]any{
+			"uri":         p.TextDocument.URI,
+			"diagnostics": []lspDiagnostic{},
+		})
+	case "textDocument/definition", "textDocument/completion", "textDocument/hover":
+		var p lspTextDocumentPositionParams
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &lspError{Code: lspInvalidParams, Message: err.Error()}
+		}
+		text, ok := s.docs[p.TextDocument.URI]
+		if !ok {
+			return nil, &lspError{Code: lspInvalidParams, Message: "document is not open: " + p.TextDocument.URI}
+		}
+		path := lspPath(p.TextDocument.URI)
+		offset := lspOffset(text, p.Position)
+		switch method {
+		case "textDocument/definition":
+			return s.definition(path, text, offset), nil
+		case "textDocument/completion":
+			return s.completion(path, text, offset), nil
+		default:
+			return s.hover(path, text, offset), nil
+		}
+	default:
+		if strings.HasPrefix(method, "$/") || method == "initialized" {
+			// Optional notifications.
+			return nil, nil
+		}
+		return nil, &lspError{Code: lspMethodNotFound, Message: "method not found: " + method}
+	}
+}
+
+// reply writes the response to the request with the given ID.
+func (s *lspServer) reply(id json.RawMessage, result any, rpcErr *lspError) error {
+	msg := lspMessage{JSONRPC: "2.0", ID: id, Error: rpcErr}
+	if id == nil {
+		msg.ID = json.RawMessage("null")
+	}
+	if rpcErr == nil {
+		var err error
+		msg.Result, err = json.Marshal(result)
+		if err != nil {
+			return err
+		}
+	}
+	return s.write(msg)
+}
+
+// notify writes a notification.
+func (s *lspServer) notify(method string, params any) *lspError {
+	data, err := json.Marshal(params)
+	if err == nil {
+		err = s.write(lspMessage{JSONRPC: "2.0", Method: method, Params: data})
+	}
+	if err != nil {
+		return &lspError{Code: lspInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *lspServer) write(msg lspMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(s.w, "Content-Length: %d\r\n\r\n%s", len(data), data)
+	return err
+}
+
+// publishDiagnostics reports the errors in the document:
+// syntax errors and partials that cannot be found.
+func (s *lspServer) publishDiagnostics(uri string) *lspError {
+	return s.notify("textDocument/publishDiagnostics", map[string]any{
+		"uri":         uri,
+		"diagnostics": templateDiagnostics(lspPath(uri), s.docs[uri]),
+	})
+}
+
+// templateDiagnostics returns the errors in the template at path with the given text.
+func templateDiagnostics(path, text string) []lspDiagnostic {
+	diagnostics := []lspDiagnostic{}
+	tags, err := syntax.ParseOptions(text, &syntax.Options{Filters: true})
+	if err == nil {
+		_, err = syntax.Pragmas(tags)
+	}
+	if err != nil {
+		// Errors start with the line number.
+		line := 1
+		msg := err.Error()
+		if prefix, rest, ok := strings.Cut(msg, ": "); ok {
+			if n, err := strconv.Atoi(prefix); err == nil {
+				line, msg = n, rest
+			}
+		}
+		diagnostics = append(diagnostics, lspDiagnostic{
+			Range:    lspLineRange(text, line-1),
+			Severity: lspSeverityError,
+			Source:   programName,
+			Message:  msg,
+		})
+		return diagnostics
+	}
+	for _, t := range scanTags(text) {
+		if t.sigil != '>' && t.sigil != '<' {
+			continue
+		}
+		if _, err := os.Stat(partialPath(path, t.name)); err != nil {
+			diagnostics = append(diagnostics, lspDiagnostic{
+				Range:    lspTagRange(text, t),
+				Severity: lspSeverityWarning,
+				Source:   programName,
+				Message:  fmt.Sprintf("partial %s not found (it will render as empty)", t.name),
+			})
+		}
+	}
+	return diagnostics
+}
+
+// definition returns the location of the partial or parent template
+// named by the tag at offset, or nil if there is none.
+func (s *lspServer) definition(path, text string, offset int) *lspLocation {
+	t, ok := tagAt(scanTags(text), offset)
+	if !ok || t.sigil != '>' && t.sigil != '<' {
+		return nil
+	}
+	target := partialPath(path, t.name)
+	if _, err := os.Stat(target); err != nil {
+		return nil
+	}
+	return &lspLocation{URI: lspURI(target)}
+}
+
+// completion returns the names of the blocks that a {{$block}} tag being typed at offset
+// can override: the blocks of the parent template it is inside.
+func (s *lspServer) completion(path, text string, offset int) []lspCompletionItem {
+	items := []lspCompletionItem{}
+	tags := scanTags(text[:offset])
+	if len(tags) == 0 || !tags[len(tags)-1].unclosed || tags[len(tags)-1].sigil != '$' {
+		return items
+	}
+	stack := enclosingTags(tags[:len(tags)-1], offset)
+	if len(stack) == 0 || stack[len(stack)-1].sigil != '<' {
+		return items
+	}
+	parent := stack[len(stack)-1].name
+	for _, name := range templateBlocks(partialPath(path, parent), make(map[string]bool)) {
+		items = append(items, lspCompletionItem{
+			Label:  name,
+			Kind:   lspCompletionItemProperty,
+			Detail: "block in " + parent,
+		})
+	}
+	return items
+}
+
+// templateBlocks returns the sorted names of the blocks
+// declared by the template file at path and the templates it includes.
+// visited is the set of files already searched.
+func templateBlocks(path string, visited map[string]bool) []string {
+	if visited[path] {
+		return nil
+	}
+	visited[path] = true
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	tags, err := syntax.ParseOptions(string(source), &syntax.Options{Filters: true})
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for t := range syntax.WalkTags(tags) {
+		switch t.Type {
+		case syntax.Block:
+			names = append(names, t.S)
+		case syntax.Partial, syntax.Parent:
+			names = append(names, templateBlocks(partialPath(path, t.S), visited)...)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// hover returns information about the Go field that the variable or section tag at offset refers to,
+// or nil if the template has no Go data type or the field cannot be found.
+func (s *lspServer) hover(path, text string, offset int) *lspHover {
+	// Scan the template for its GO_TYPE pragma
+	// so that hovering works while the template has errors.
+	tags := scanTags(text)
+	dataType := s.dataType
+	for _, t := range tags {
+		if name, value, _ := strings.Cut(t.name, "="); t.sigil == '%' && strings.TrimSpace(name) == "GO_TYPE" {
+			dataType = strings.TrimSpace(value)
+		}
+	}
+	if dataType == "" {
+		return nil
+	}
+	t, ok := tagAt(tags, offset)
+	if !ok || t.name == "." || !strings.Contains("\x00&#^", string(t.sigil)) {
+		return nil
+	}
+	root, err := s.types.dataType(dataType, filepath.Dir(path))
+	if err != nil {
+		return nil
+	}
+
+	// Sections push their values onto the context stack.
+	contexts := []*goTypeRef{root}
+	for _, section := range enclosingTags(tags, t.start) {
+		if section.sigil != '#' {
+			continue
+		}
+		f := s.types.lookup(contexts, section.name)
+		var ctx *goTypeRef
+		if f != nil {
+			ctx = s.types.elem(f.typ)
+		}
+		contexts = append(contexts, ctx)
+	}
+	f := s.types.lookup(contexts, t.name)
+	if f == nil {
+		return nil
+	}
+	h := new(lspHover)
+	h.Contents.Kind = "markdown"
+	h.Contents.Value = "```go\n" + f.String() + "\n```"
+	if f.doc != "" {
+		h.Contents.Value += "\n\n" + f.doc
+	}
+	h.Range = lspTagRange(text, t)
+	return h
+}
+
+// tagAt returns the tag that contains offset.
+func tagAt(tags []scannedTag, offset int) (scannedTag, bool) {
+	for _, t := range tags {
+		if t.start <= offset && offset < t.end {
+			return t, true
+		}
+	}
+	return scannedTag{}, false
+}
+
+// enclosingTags returns the section, parent, and block tags
+// that are open at offset, from outermost to innermost.
+func enclosingTags(tags []scannedTag, offset int) []scannedTag {
+	var stack []scannedTag
+	for _, t := range tags {
+		if t.start >= offset {
+			break
+		}
+		switch t.sigil {
+		case '#', '^', '<', '$':
+			stack = append(stack, t)
+		case '/':
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].name == t.name {
+					stack = stack[:i]
+					break
+				}
+			}
+		}
+	}
+	return stack
+}
+
+// partialPath returns the path of the partial with the given name
+// used by the template at path.
+func partialPath(path, name string) string {
+	return filepath.Join(filepath.Dir(path), name+".mustache")
+}
+
+// lspPath returns the file path of a file URI.
+func lspPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+// lspURI returns the file URI of a file path.
+func lspURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// lspOffset returns the byte offset in text of an LSP position,
+// whose character is counted in UTF-16 code units.
+func lspOffset(text string, pos lspPosition) int {
+	offset := 0
+	for range pos.Line {
+		i := strings.IndexByte(text[offset:], '\n')
+		if i < 0 {
+			return len(text)
+		}
+		offset += i + 1
+	}
+	for n := 0; n < pos.Character && offset < len(text) && text[offset] != '\n'; {
+		c, size := utf8.DecodeRuneInString(text[offset:])
+		n += utf16.RuneLen(c)
+		offset += size
+	}
+	return offset
+}
+
+// lspPositionOf returns the LSP position of a byte offset in text.
+func lspPositionOf(text string, offset int) lspPosition {
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+	pos := lspPosition{Line: strings.Count(text[:lineStart], "\n")}
+	for _, c := range text[lineStart:offset] {
+		pos.Character += utf16.RuneLen(c)
+	}
+	return pos
+}
+
+// lspTagRange returns the range of a tag.
+func lspTagRange(text string, t scannedTag) lspRange {
+	return lspRange{Start: lspPositionOf(text, t.start), End: lspPositionOf(text, t.end)}
+}
+
+// lspLineRange returns the range of the given zero-based line,
+// or of the last line if text has fewer lines.
+func lspLineRange(text string, line int) lspRange {
+	start := 0
+	for range line {
+		i := strings.IndexByte(text[start:], '\n')
+		if i < 0 {
+			break
+		}
+		start += i + 1
+	}
+	end := start + strings.IndexByte(text[start:], '\n')
+	if end < start {
+		end = len(text)
+	}
+	return lspRange{Start: lspPositionOf(text, start), End: lspPositionOf(text, end)}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLSP(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module foo\n",
+		"views/views.go": "package views\n" +
+			"type Page struct {\n" +
+			"\t// Title is the page's title.\n" +
+			"\tTitle string\n" +
+			"\tItems []Item\n" +
+			"\tUser\n" +
+			"}\n" +
+			"type Item struct{ Name, Price string }\n" +
+			"type User struct{ Email string }\n",
+		"templates/base.mustache":   "<title>{{$title}}{{/title}}</title>{{>nav}}{{$body}}{{/body}}",
+		"templates/nav.mustache":    "<nav>{{$nav}}{{/nav}}</nav>",
+		"templates/layout.mustache": "{{<base}}{{$title}}Site{{/title}}{{$footer}}{{/footer}}{{/base}}",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	uri := lspURI(filepath.Join(dir, "templates", "page.mustache"))
+	const page = "{{%GO_TYPE=*foo/views.Page}}\n" +
+		"<h1>{{ Title }}</h1>{{Email}}\n" +
+		"{{#Items}}{{Name}}{{/Items}}\n" +
+		"{{<layout}}{{$b"
+	const fixed = "{{%GO_TYPE=*foo/views.Page}}\n{{>missing}}\n{{<layout}}{{$body}}{{/body}}{{/layout}}\n"
+
+	input := new(bytes.Buffer)
+	id := 0
+	send := func(method string, params any) {
+		msg := map[string]any{"jsonrpc": "2.0", "method": method, "params": params}
+		if !strings.HasPrefix(method, "textDocument/did") && method != "exit" {
+			id++
+			msg["id"] = id
+		}
+		data, err := json.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(input, "Content-Length: %d\r\n\r\n%s", len(data), data)
+	}
+	position := func(line, character int) map[string]any {
+		return map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"position":     map[string]any{"line": line, "character": character},
+		}
+	}
+	send("initialize", map[string]any{})
+	send("textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": uri, "text": page}})
+	send("textDocument/completion", position(3, 15))
+	send("textDocument/definition", position(3, 5))
+	send("textDocument/hover", position(1, 8))
+	send("textDocument/hover", position(1, 22))
+	send("textDocument/hover", position(2, 13))
+	send("textDocument/hover", position(2, 0))
+	send("textDocument/didChange", map[string]any{
+		"textDocument":   map[string]any{"uri": uri},
+		"contentChanges": []map[string]any{{"text": fixed}},
+	})
+	send("shutdown", nil)
+	send("exit", nil)
+
+	output := new(bytes.Buffer)
+	if err := newLSPServer("").serve(input, output); err != nil {
+		t.Fatal(err)
+	}
+	responses := make(map[int]json.RawMessage)
+	var diagnostics [][]lspDiagnostic
+	r := textproto.NewReader(bufio.NewReader(output))
+	for {
+		header, err := r.ReadMIMEHeader()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, _ := strconv.Atoi(header.Get("Content-Length"))
+		body := make([]byte, n)
+		if _, err := io.ReadFull(r.R, body); err != nil {
+			t.Fatal(err)
+		}
+		var msg lspMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Error != nil {
+			t.Errorf("response %s: error %+v", msg.ID, msg.Error)
+		}
+		if msg.Method == "textDocument/publishDiagnostics" {
+			var p struct{ Diagnostics []lspDiagnostic }
+			if err := json.Unmarshal(msg.Params, &p); err != nil {
+				t.Fatal(err)
+			}
+			diagnostics = append(diagnostics, p.Diagnostics)
+			continue
+		}
+		id, _ := strconv.Atoi(string(msg.ID))
+		responses[id] = msg.Result
+	}
+
+	if len(diagnostics) != 2 {
+		t.Fatalf("got %d diagnostics notifications; want 2", len(diagnostics))
+	}
+	if d := diagnostics[0]; len(d) != 1 || d[0].Severity != lspSeverityError || d[0].Range.Start.Line != 3 {
+		t.Errorf("diagnostics for unclosed tag = %+v; want an error on line 3", d)
+	}
+	if d := diagnostics[1]; len(d) != 1 || d[0].Severity != lspSeverityWarning || d[0].Range != (lspRange{lspPosition{1, 0}, lspPosition{1, 12}}) {
+		t.Errorf("diagnostics for missing partial = %+v; want a warning for {{>missing}}", d)
+	}
+
+	var completions []lspCompletionItem
+	if err := json.Unmarshal(responses[2], &completions); err != nil {
+		t.Fatal(err)
+	}
+	var labels []string
+	for _, item := range completions {
+		labels = append(labels, item.Label)
+	}
+	if got, want := strings.Join(labels, " "), "body footer nav title"; got != want {
+		t.Errorf("completions = %q; want %q", got, want)
+	}
+
+	var location lspLocation
+	if err := json.Unmarshal(responses[3], &location); err != nil {
+		t.Fatal(err)
+	}
+	if want := lspURI(filepath.Join(dir, "templates", "layout.mustache")); location.URI != want {
+		t.Errorf("definition = %q; want %q", location.URI, want)
+	}
+
+	hovers := []struct {
+		id   int
+		want string
+	}{
+		{4, "```go\nfield Title string\n```\n\nTitle is the page's title."},
+		{5, "```go\nfield Email string\n```"},
+		{6, "```go\nfield Name string\n```"},
+		{7, "```go\nfield Items []Item\n```"},
+	}
+	for _, test := range hovers {
+		var h lspHover
+		if err := json.Unmarshal(responses[test.id], &h); err != nil {
+			t.Fatalf("hover %d: %v", test.id, err)
+		}
+		if h.Contents.Value != test.want {
+			t.Errorf("hover %d = %q; want %q", test.id, h.Contents.Value, test.want)
+		}
+	}
+	if string(responses[8]) != "null" {
+		t.Errorf("shutdown result = %s; want null", responses[8])
+	}
+}
+
+func TestLSPInvalidContentLength(t *testing.T) {
+	for _, length := range []string{"-1", "x", "1099511627776"} {
+		input := strings.NewReader("Content-Length: " + length + "\r\n\r\n{}")
+		if err := newLSPServer("").serve(input, io.Discard); err == nil {
+			t.Errorf("serve with Content-Length %s did not return an error", length)
+		}
+	}
+}
+
+func TestLSPPosition(t *testing.T) {
+	const text = "aé😀\nb"
+	for offset, pos := range map[int]lspPosition{0: {0, 0}, 3: {0, 2}, 7: {0, 4}, 9: {1, 1}} {
+		if got := lspPositionOf(text, offset); got != pos {
+			t.Errorf("lspPositionOf(%q, %d) = %+v; want %+v", text, offset, got, pos)
+		}
+		if got := lspOffset(text, pos); got != offset {
+			t.Errorf("lspOffset(%q, %+v) = %d; want %d", text, pos, got, offset)
+		}
+	}
+}
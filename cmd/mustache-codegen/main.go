@@ -8,73 +8,208 @@ import (
 	"flag"
 	"fmt"
 	"io"
-	"iter"
 	"os"
 	"path/filepath"
-	"slices"
+	"strconv"
 	"strings"
-	"unicode"
-)
-
-type tag struct {
-	tt             tagType
-	s              string
-	indent         string
-	indentArgument bool // whether to indent an argument block that replaces this parameter block
-	body           []tag
-}
-
-type tagType int
-
-const (
-	literal tagType = iota
-	variable
-	rawVariable
-	section
-	invertedSection
-	partial
-	block
-	parent
 
-	// indentPoint is a directive used to indicate where block indents should be inserted
-	// (i.e. at the beginning of logical lines).
-	indentPoint
+	"github.com/kagisearch/mustache-codegen/go/mustache"
 )
 
 const programName = "mustache-codegen"
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "extract":
+			extractMain(os.Args[2:])
+			return
+		case "lsp":
+			lspMain(os.Args[2:])
+			return
+		case "fmt":
+			fmtMain(os.Args[2:])
+			return
+		case "render":
+			renderMain(os.Args[2:])
+			return
+		case "spec":
+			specMain(os.Args[2:])
+			return
+		}
+	}
+
 	fset := flag.FlagSet{Usage: func() {}}
 	generatorName := fset.String("lang", "", "`language` to generate code for (js or go)")
-	goPkgName := fset.String("go-package", "main", "Go package `name`")
+	goOpts := new(goOptions)
+	fset.StringVar(&goOpts.packageName, "go-package", "main", "Go package `name`")
+	fset.StringVar(&goOpts.funcName, "go-func", "", "Go function `name` (default derived from the template name)")
+	fset.BoolVar(&goOpts.unexported, "go-unexported", false, "derive an unexported Go function name from the template name")
+	fset.StringVar(&goOpts.receiver, "go-receiver", "", "generate a Go method on the given receiver `type` (e.g. T or *T)")
+	fset.StringVar(&goOpts.buildTags, "go-build-tags", "", "Go build constraint `expression` for the generated file")
+	fset.BoolVar(&goOpts.interpret, "go-interpret", false, "generate a Go function that interprets the template file at run time, for development in the source tree")
+	fset.BoolVar(&goOpts.translator, "go-translator", false, "add an m.Translator parameter to the Go function for translating {{#_i18n}} sections")
+	fset.StringVar(&goOpts.dataType, "go-type", "", "`type` of the Go function's data parameter, as in *example.com/app/views.Page (default any)")
+	goFilters := fset.String("go-filters", "", "import `path` of a Go package whose functions variables can be filtered with, as in {{price | currency}}")
+	jsOpts := new(jsOptions)
+	fset.Func("escape", "`set` of characters to escape in variables: quotes (&<>\"', the default), minimal (&<>\"), or none", func(s string) error {
+		e, ok := mustache.ParseEscaper(s)
+		if !ok {
+			return fmt.Errorf("unknown escaper %q", s)
+		}
+		goOpts.escaper = e
+		jsOpts.escaper = e
+		return nil
+	})
+	fset.Func("js-mode", "JavaScript function `mode`: string (return a string) or stream (an async generator that yields chunks)", func(s string) error {
+		switch s {
+		case "string":
+			jsOpts.stream = false
+		case "stream":
+			jsOpts.stream = true
+		default:
+			return fmt.Errorf("unknown mode %q", s)
+		}
+		return nil
+	})
+	fset.StringVar(&jsOpts.format, "js-format", "esm", "JavaScript module `format`: esm, cjs (CommonJS), or iife (a global variable for a script tag)")
+	fset.StringVar(&jsOpts.name, "js-name", "", "JavaScript export or global variable `name` (default is the default export, or derived from the template name for iife)")
+	emitRuntime := false
+	fset.Func("js-runtime", "where JavaScript helpers come from: inline (the default), import:`path` (a module generated with -js-runtime=module), or module (generate that module instead of a template)", func(s string) error {
+		switch path, ok := strings.CutPrefix(s, "import:"); {
+		case s == "inline":
+			jsOpts.runtime = ""
+			emitRuntime = false
+		case s == "module":
+			jsOpts.runtime = ""
+			emitRuntime = true
+		case ok && path != "":
+			jsOpts.runtime = path
+			emitRuntime = false
+		default:
+			return fmt.Errorf("unknown runtime %q", s)
+		}
+		return nil
+	})
+	fset.BoolVar(&jsOpts.pretty, "js-pretty", false, "format generated JavaScript for reading")
+	fset.BoolVar(&jsOpts.translator, "js-translator", false, "add a translator function parameter to the JavaScript functions for translating {{#_i18n}} sections")
+	jsFilters := fset.String("js-filters", "", "relative import `path` of a JavaScript module whose exports variables can be filtered with, as in {{price | currency}}")
+	fset.BoolFunc("strict", "make variables that cannot be found errors at run time", func(s string) error {
+		b, err := strconv.ParseBool(s)
+		goOpts.strict = b
+		jsOpts.strict = b
+		return err
+	})
+	fset.BoolFunc("minify-html", "collapse whitespace and remove comments in the template's HTML", func(s string) error {
+		b, err := strconv.ParseBool(s)
+		goOpts.minifyHTML = b
+		jsOpts.minifyHTML = b
+		return err
+	})
+	fset.BoolFunc("front-matter", "parse a block between two --- lines at the start of a template as metadata instead of text", func(s string) error {
+		b, err := strconv.ParseBool(s)
+		goOpts.frontMatter = b
+		jsOpts.frontMatter = b
+		return err
+	})
+	var translations []*translation
+	fset.Func("catalog", "translate the template at compile time with the message catalog in `locale=file` (.po or .json); may be repeated", func(s string) error {
+		locale, path, ok := strings.Cut(s, "=")
+		if !ok {
+			return fmt.Errorf("missing =FILE in %q", s)
+		}
+		if _, err := localeSuffix(locale); err != nil {
+			return err
+		}
+		messages, err := loadCatalog(path)
+		if err != nil {
+			return err
+		}
+		translations = append(translations, &translation{locale: locale, messages: messages})
+		return nil
+	})
+	catalogFallback := fset.Bool("catalog-fallback", false, "render messages missing from a -catalog untranslated instead of failing")
 	outputFile := fset.String("o", "", "output `file`")
-	if err := fset.Parse(os.Args[1:]); err != nil || fset.NArg() > 1 || *generatorName == "" {
-		fmt.Fprintf(fset.Output(), "usage: %s -lang=LANG [options] TEMPLATE\n\n", programName)
+	if err := fset.Parse(os.Args[1:]); err != nil || *generatorName == "" {
+		fmt.Fprintf(fset.Output(), "usage: %s -lang=LANG [options] TEMPLATE\n", programName)
+		fmt.Fprintf(fset.Output(), "       %s -lang=js [options] TEMPLATE...\n", programName)
+		fmt.Fprintf(fset.Output(), "       %s extract [options] TEMPLATE...\n", programName)
+		fmt.Fprintf(fset.Output(), "       %s fmt [options] [TEMPLATE...]\n", programName)
+		fmt.Fprintf(fset.Output(), "       %s lsp [options]\n", programName)
+		fmt.Fprintf(fset.Output(), "       %s render [options] TEMPLATE\n", programName)
+		fmt.Fprintf(fset.Output(), "       %s spec [options]\n\n", programName)
 		fset.PrintDefaults()
 		if errors.Is(err, flag.ErrHelp) {
 			return
 		}
 		os.Exit(64) // EX_USAGE
 	}
+	// templateFiles maps template names to their files
+	// for reporting problems with translations.
+	templateFiles := make(map[string]string)
+	for _, tr := range translations {
+		tr.fallback = *catalogFallback
+		tr.file = func(template, partial string) string {
+			file := templateFiles[template]
+			if partial != "" {
+				file = filepath.Join(filepath.Dir(file), partial+".mustache")
+			}
+			return file
+		}
+		tr.missing = func(pos, message string) {
+			fmt.Fprintf(os.Stderr, "%s: no %s translation for %q\n", pos, tr.locale, message)
+		}
+	}
+	goOpts.translations = translations
+	jsOpts.translations = translations
+	var err error
+	switch {
+	case *generatorName == "go" && *goFilters != "":
+		goOpts.filters, err = loadGoFilters(*goFilters, ".")
+	case *generatorName == "js" && *jsFilters != "":
+		jsOpts.filters, err = loadJSFilters(*jsFilters, *outputFile)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: filters: %v\n", programName, err)
+		os.Exit(1)
+	}
 
-	var templateName string
-	templateDir := "."
-	load := func(name string) (string, error) {
-		data, err := os.ReadFile(filepath.Join(templateDir, name+".mustache"))
-		if os.IsNotExist(err) {
-			return "", nil
+	if emitRuntime {
+		if *generatorName != "js" || fset.NArg() > 0 {
+			fmt.Fprintf(os.Stderr, "%s: -js-runtime=module takes no templates and requires -lang=js\n", programName)
+			os.Exit(64) // EX_USAGE
 		}
+		output, err := compileJSRuntime(jsOpts)
 		if err != nil {
-			return "", err
+			fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
+			os.Exit(1)
 		}
-		return string(data), nil
+		writeOutput(*outputFile, output)
+		return
+	}
+	if fset.NArg() > 1 {
+		if *generatorName != "js" {
+			fmt.Fprintf(os.Stderr, "%s: multiple templates are only supported with -lang=js\n", programName)
+			os.Exit(64) // EX_USAGE
+		}
+		for _, fname := range fset.Args() {
+			templateFiles[strings.TrimSuffix(filepath.Base(fname), ".mustache")] = fname
+		}
+		bundle(fset.Args(), jsOpts, *outputFile)
+		return
+	}
+
+	var templateName string
+	templateDir := "."
+	load := func(name string) (string, error) {
+		return loadFrom(templateDir)(name)
 	}
 	generator := map[string]func(string) ([]byte, error){
 		"go": func(source string) ([]byte, error) {
-			return compileGo(*goPkgName, templateName, source, load)
+			return compileGo(templateName, source, load, goOpts)
 		},
 		"js": func(source string) ([]byte, error) {
-			return compileJS(source, load)
+			return compileJS(templateName, source, load, jsOpts)
 		},
 	}[*generatorName]
 	if generator == nil {
@@ -83,14 +218,18 @@ func main() {
 	}
 
 	var input []byte
-	var err error
 	if fname := fset.Arg(0); fname != "" {
 		templateName = strings.TrimSuffix(filepath.Base(fname), ".mustache")
 		templateDir = filepath.Dir(fname)
+		templateFiles[templateName] = fname
 		input, err = os.ReadFile(fname)
+		if err == nil {
+			goOpts.templatePath, err = relativeTemplatePath(*outputFile, fname)
+		}
 	} else {
 		input, err = io.ReadAll(os.Stdin)
 		templateName = "stdin"
+		templateFiles[templateName] = "<stdin>"
 	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
@@ -102,479 +241,80 @@ func main() {
 		fmt.Fprintf(os.Stderr, "%s: %s %v\n", programName, templateName, err)
 		os.Exit(1)
 	}
-	if *outputFile == "" {
-		_, err = os.Stdout.Write(output)
-	} else {
-		err = os.WriteFile(*outputFile, output, 0o666)
-	}
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
-		os.Exit(1)
-	}
-}
-
-const (
-	defaultStartDelim = "{{"
-	defaultEndDelim   = "}}"
-)
-
-func parse(s string) ([]tag, error) {
-	type scope struct {
-		start      tag
-		lineno     int
-		slice      *[]tag
-		standalone bool
-	}
-
-	var result []tag
-	startDelim := defaultStartDelim
-	endDelim := defaultEndDelim
-
-	stack := []scope{
-		{slice: &result},
-	}
-
-	lineno := 1
-	newScope := func(newTag tag) {
-		curr := stack[len(stack)-1].slice
-		*curr = append(*curr, newTag)
-		stack = append(stack, scope{
-			start:  newTag,
-			lineno: lineno,
-			slice:  &(*curr)[len(*curr)-1].body,
-		})
-	}
-
-	appendLiteral := func(s string) {
-		if s != "" {
-			curr := stack[len(stack)-1].slice
-			*curr = append(*curr, tag{
-				tt: literal,
-				s:  s,
-			})
-		}
-	}
-
-	dedent := func(s string) string {
-		for _, curr := range stack {
-			if curr.start.tt != block {
-				continue
-			}
-			var hasIndent bool
-			s, hasIndent = strings.CutPrefix(s, curr.start.indent)
-			if !hasIndent {
-				break
-			}
-		}
-		return s
-	}
-
-	// Process (roughly) one line at a time.
-	for len(s) > 0 {
-		s = dedent(s)
-		eol := indexNextLine(s)
-
-		tagStart := strings.Index(s[:eol], startDelim)
-		if tagStart < 0 {
-			// Add indent point if there are no tags.
-			curr := stack[len(stack)-1].slice
-			*curr = append(*curr, tag{tt: indentPoint})
-		}
-
-		// Line has one or more tags.
-		// Hold off on adding literals until we know whether the line is standalone.
-		prevEnd := 0
-		for tagStart >= 0 {
-			special, key, tagEnd, err := cutTag(s, tagStart, startDelim, endDelim)
-			if err != nil {
-				return nil, fmt.Errorf("%d: %v", lineno, err)
-			}
-			if n := strings.Count(s[tagStart:tagEnd], "\n"); n > 0 {
-				// Tag spanned multiple lines (comment).
-				// Update line position variables.
-				lineno += n
-				eol = tagEnd + indexNextLine(s[tagEnd:])
-			}
-			if special != '!' && special != '=' {
-				// Non-comments must contain a non-whitespace character sequence.
-				if key == "" {
-					return nil, fmt.Errorf("%d: empty tag", lineno)
-				}
-				if i := strings.IndexFunc(key, unicode.IsSpace); i >= 0 {
-					return nil, fmt.Errorf("%d: extra words in %s tag", lineno, key[:i])
-				}
-			}
-
-			// "Standalone" tags are those that have nothing except whitespace
-			// before or after them on a line.
-			// Such tags are treated as though the leading whitespace the rest of the line were not present.
-			// "Standalone pair" tags are those where the whitespace after their closing tag
-			// is considered instead of the whitespace after the tag itself.
-			leadingText := s[prevEnd:tagStart]
-			restOfLine := s[tagEnd:eol]
-			trailingText := restOfLine
-			isParameter := special == '$' && stack[len(stack)-1].start.tt != parent
-			isArgument := special == '$' && stack[len(stack)-1].start.tt == parent
-			isStandalonePairTag := special == '<' || isParameter
-			if isStandalonePairTag {
-				i, err := elementEnd(key, s, tagEnd, startDelim, endDelim)
-				if err != nil {
-					return nil, fmt.Errorf("%d: %v", lineno, err)
-				}
-				trailingText = s[i : i+indexNextLine(s[i:])]
-			}
-			isStandalone := prevEnd == 0 &&
-				special != 0 && special != '&' &&
-				isSpace(leadingText) && isSpace(trailingText)
-			ignoreRestOfLine := isStandalone && !isStandalonePairTag
-
-			// Compute indentation.
-			var indent string
-			switch {
-			// Argument tags and standalone parameter tags that clear at the end
-			// use the following line's indentation.
-			case (isArgument || isParameter && isStandalone) && isSpace(restOfLine):
-				indent = lineIndentation(dedent(s[eol:]))
-				// Such tags also ignore the newline before their content.
-				ignoreRestOfLine = true
-			// Standalone tags use the indentation from their line.
-			case isStandalone:
-				indent = leadingText
-			}
-
-			// Add any literal text encountered since the last tag.
-			if !isStandalone {
-				// If this is the first tag we're processing on the line
-				// and it's not the argument block close tag at the start of the line,
-				// then add an indent point.
-				// The special argument block close tag is necessary
-				// because we want "{{$foo}}\n  foo\n{{/foo}}" to be treated as "foo\n".
-				// If we added an insert point, then we would add an extra indent after the newline
-				// during template execution.
-				if prevEnd == 0 && !(tagStart == 0 && special == '/' && stack[len(stack)-1].start.tt == block && stack[len(stack)-2].start.tt == parent) {
-					curr := stack[len(stack)-1].slice
-					*curr = append(*curr, tag{tt: indentPoint})
-				}
-				appendLiteral(leadingText)
-			}
-
-			switch special {
-			case '#':
-				// Section.
-				newScope(tag{
-					tt: section,
-					s:  key,
-				})
-			case '^':
-				// Inverted section.
-				newScope(tag{
-					tt: invertedSection,
-					s:  key,
-				})
-			case '!':
-				// Comment.
-			case '>':
-				// Partial.
-				curr := stack[len(stack)-1].slice
-				*curr = append(*curr, tag{
-					tt:     partial,
-					s:      key,
-					indent: indent,
-				})
-			case '$':
-				// Block.
-				newScope(tag{
-					tt:             block,
-					s:              key,
-					indent:         indent,
-					indentArgument: isStandalone && isParameter && isSpace(restOfLine),
-				})
-				// If there's more content on the line,
-				// then add an indent point to act like the beginning of a line.
-				if isArgument && !ignoreRestOfLine {
-					curr := stack[len(stack)-1].slice
-					*curr = append(*curr, tag{tt: indentPoint})
-				}
-			case '<':
-				// Parent.
-				newScope(tag{
-					tt:     parent,
-					s:      key,
-					indent: indent,
-				})
-				stack[len(stack)-1].standalone = isStandalone
-			case '/':
-				// Closing tag.
-				last := len(stack) - 1
-				if last == 0 {
-					return nil, fmt.Errorf("%d: %s/%s%s without opening", lineno, startDelim, key, endDelim)
-				}
-				if stack[last].start.tt == parent {
-					// We already computed whether the closing tag clears when we opened the tag.
-					ignoreRestOfLine = stack[last].standalone
-				}
-				if want := stack[last].start.s; key != want {
-					return nil, fmt.Errorf("%d: mismatched %s/%s%s (last opened %s on line %d)",
-						lineno, startDelim, key, endDelim, want, stack[last].lineno)
-				}
-				stack[last] = scope{}
-				stack = stack[:last]
-			case '=':
-				// Set delimiter tag.
-				var err error
-				startDelim, endDelim, err = splitSetDelimiterTag(key)
-				if err != nil {
-					return nil, fmt.Errorf("%d: %v", lineno, err)
-				}
-			case '&':
-				// Raw variable.
-				curr := stack[len(stack)-1].slice
-				*curr = append(*curr, tag{
-					tt: rawVariable,
-					s:  key,
-				})
-			default:
-				// Escaped variable.
-				curr := stack[len(stack)-1].slice
-				*curr = append(*curr, tag{
-					tt: variable,
-					s:  key,
-				})
-			}
-
-			// Move to next tag in the line.
-			if ignoreRestOfLine {
-				prevEnd = eol
-				break
-			}
-			prevEnd = tagEnd
-			tagStart = nextIndex(s[:eol], tagEnd, startDelim)
-		}
-
-		// After we've processed all the tags in the line,
-		// add any remaining text as a literal
-		// and move on to the next line.
-		appendLiteral(s[prevEnd:eol])
-		s = s[eol:]
-		lineno++
-	}
-
-	// Return an error if there are open tags.
-	if i := len(stack) - 1; i > 0 {
-		last := stack[i]
-		return nil, fmt.Errorf("%d: unclosed %s", last.lineno, last.start.s)
-	}
-
-	return result, nil
+	writeOutput(*outputFile, output)
 }
 
-// cutTag parses the tag that starts at the index tagStart in s.
-// It is assumed that strings.HasPrefix(s[tagStart:], startDelim) reports true.
-func cutTag(s string, tagStart int, startDelim, endDelim string) (b byte, key string, tagEnd int, err error) {
-	// Find end of tag.
-	tagInnerStart := tagStart + len(startDelim)
-	isComment := strings.HasPrefix(s[tagInnerStart:], "!")
-	tagInnerEnd := tagInnerStart
-	tagEnd = -1
-	for ; tagInnerEnd+len(endDelim) <= len(s); tagInnerEnd++ {
-		if s[tagInnerEnd] == '\n' && !isComment {
-			// Newlines only permitted in comments.
-			return 0, "", -1, errors.New("unclosed tag")
-		}
-		if i := tagInnerEnd + len(endDelim); s[tagInnerEnd:i] == endDelim {
-			tagEnd = i
-			break
-		}
-	}
-	if tagEnd < 0 {
-		if isComment {
-			return 0, "", -1, errors.New("unclosed comment")
+// bundle compiles the given template files into a single JavaScript module.
+func bundle(fnames []string, opts *jsOptions, outputFile string) {
+	templates := make([]jsTemplate, len(fnames))
+	for i, fname := range fnames {
+		input, err := os.ReadFile(fname)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
+			os.Exit(1)
 		}
-		return 0, "", -1, errors.New("unclosed tag")
-	}
-
-	// Check for triple-bracketed (raw) variable.
-	// {{{foo}}} is treated identically to {{&foo}}.
-	isDefault := startDelim == "{{" && endDelim == "}}"
-	if isDefault && s[tagInnerStart] == '{' && strings.HasPrefix(s[tagEnd:], "}") {
-		tagInnerStart++
-		tagEnd++
-		return '&', strings.TrimSpace(s[tagInnerStart:tagInnerEnd]), tagEnd, nil
-	}
-
-	// Extract first character if it's one of the known specials.
-	inner := s[tagInnerStart:tagInnerEnd]
-	if inner, isDelimiter := strings.CutPrefix(inner, "="); isDelimiter {
-		inner, hasFinalEquals := strings.CutSuffix(inner, "=")
-		if !hasFinalEquals {
-			return '=', inner, tagEnd, fmt.Errorf("%s does not end with =%s", s[tagStart:tagEnd], endDelim)
+		dir := filepath.Dir(fname)
+		templates[i] = jsTemplate{
+			name:   strings.TrimSuffix(filepath.Base(fname), ".mustache"),
+			source: string(input),
+			dir:    dir,
+			load:   loadFrom(dir),
 		}
-		return '=', strings.TrimSpace(inner), tagEnd, nil
-	}
-	if len(inner) > 1 && strings.IndexByte(`#^!<>$/&`, inner[0]) >= 0 {
-		b = inner[0]
-		inner = inner[1:]
-	}
-	return b, strings.TrimSpace(inner), tagEnd, nil
-}
-
-// splitSetDelimiterTag splits the inner content of a set delimiter tag
-// into the start and end delimiters.
-func splitSetDelimiterTag(s string) (startDelim, endDelim string, err error) {
-	i := strings.IndexFunc(s, unicode.IsSpace)
-	if i == 0 {
-		return "", "", errors.New("set delimiter tag empty")
 	}
-	if i < 0 {
-		return "", "", errors.New("set delimiter tag missing an end delimiter")
-	}
-	j := nextIndexFunc(s, i, isNonSpace)
-	if j < 0 {
-		return "", "", errors.New("set delimiter tag missing an end delimiter")
-	}
-	if k := nextIndexFunc(s, j, unicode.IsSpace); k >= 0 {
-		return "", "", errors.New("set delimiter tag has more than two delimiters")
+	output, err := compileJSBundle(templates, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
+		os.Exit(1)
 	}
-	return s[:i], s[j:], nil
+	writeOutput(outputFile, output)
 }
 
-// elementEnd returns the end of the matching end tag.
-// The search starts at tagEnd,
-// the index in s of the end of the start tag with the given name.
-func elementEnd(name string, s string, tagEnd int, startDelim, endDelim string) (int, error) {
-	level := 1
-	i := tagEnd
-	for level > 0 {
-		tagStart := nextIndex(s, i, startDelim)
-		if tagStart < 0 {
-			return -1, fmt.Errorf("unclosed %s", name)
+// loadFrom returns a function that loads partials from the given directory.
+// Missing partials are treated as empty.
+func loadFrom(dir string) func(name string) (string, error) {
+	return func(name string) (string, error) {
+		data, err := os.ReadFile(filepath.Join(dir, name+".mustache"))
+		if os.IsNotExist(err) {
+			return "", nil
 		}
-		special, key, tagEnd, err := cutTag(s, tagStart, startDelim, endDelim)
 		if err != nil {
-			return -1, fmt.Errorf("unclosed %s", name)
-		}
-		switch special {
-		case '#', '^', '$', '<':
-			if key == name {
-				level++
-			}
-		case '/':
-			if key == name {
-				level--
-			}
-		case '=':
-			var err error
-			startDelim, endDelim, err = splitSetDelimiterTag(key)
-			if err != nil {
-				return 0, err
-			}
-		}
-		i = tagEnd
-	}
-	return i, nil
-}
-
-// walkTags returns an iterator that visits all tags in the given slice in pre-order.
-func walkTags(tags []tag) iter.Seq[tag] {
-	var walk func(tags []tag, yield func(tag) bool) bool
-	walk = func(tags []tag, yield func(tag) bool) bool {
-		for _, t := range tags {
-			if !yield(t) {
-				return false
-			}
-			if !walk(t.body, yield) {
-				return false
-			}
-		}
-		return true
-	}
-	return func(yield func(tag) bool) {
-		walk(tags, yield)
-	}
-}
-
-// condenseLiteralsWithoutIndentation joins the strings of the leading [literal] tags in the slice
-// into a single literal tag and returns how many were used.
-// Any [indentPoint] tags are ignored.
-func condenseLiteralsWithoutIndentation(tags []tag) (_ tag, n int) {
-	sb := new(strings.Builder)
-	for i, t := range tags {
-		switch t.tt {
-		case literal:
-			sb.WriteString(t.s)
-		case indentPoint:
-			// Skip.
-		default:
-			return tag{
-				tt: literal,
-				s:  sb.String(),
-			}, i
+			return "", err
 		}
+		return string(data), nil
 	}
-	return tag{
-		tt: literal,
-		s:  sb.String(),
-	}, len(tags)
 }
 
-func tagsEqual(t1, t2 tag) bool {
-	if t1.tt != t2.tt || t1.s != t2.s || t1.indent != t2.indent {
-		return false
+// writeOutput writes the generated code to the output file,
+// or to standard output if outputFile is empty.
+func writeOutput(outputFile string, output []byte) {
+	var err error
+	if outputFile == "" {
+		_, err = os.Stdout.Write(output)
+	} else {
+		err = os.WriteFile(outputFile, output, 0o666)
 	}
-	return slices.EqualFunc(t1.body, t2.body, tagsEqual)
-}
-
-// nextIndex is like [strings.Index],
-// but takes in a starting index.
-// nextIndex will not return a value less than start
-// unless substr is not found within s[start:],
-// in which case nextIndex will return -1.
-func nextIndex(s string, start int, substr string) int {
-	i := strings.Index(s[start:], substr)
-	if i < 0 {
-		return i
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
+		os.Exit(1)
 	}
-	return start + i
 }
 
-// nextIndexFunc is like [strings.IndexFunc],
-// but takes in a starting index.
-// nextIndexFunc will not return a value less than start
-// unless substr is not found within s[start:],
-// in which case nextIndexFunc will return -1.
-func nextIndexFunc(s string, start int, f func(rune) bool) int {
-	i := strings.IndexFunc(s[start:], f)
-	if i < 0 {
-		return i
+// relativeTemplatePath returns the slash-separated path to the template file
+// relative to the directory of the output file.
+// An empty output file refers to standard output,
+// which is treated as a file in the working directory.
+func relativeTemplatePath(outputFile, templateFile string) (string, error) {
+	outputDir, err := filepath.Abs(filepath.Dir(outputFile))
+	if err != nil {
+		return "", err
 	}
-	return start + i
-}
-
-// indexNextLine returns the index of the last byte of the first line of s (exclusive).
-func indexNextLine(s string) int {
-	i := strings.IndexByte(s, '\n')
-	if i < 0 {
-		return len(s)
+	templateFile, err = filepath.Abs(templateFile)
+	if err != nil {
+		return "", err
 	}
-	return i + 1
-}
-
-// lineIndentation returns the longest whitespace-only prefix of line.
-func lineIndentation(line string) string {
-	firstNonSpace := strings.IndexFunc(line, func(c rune) bool {
-		return !unicode.Is(unicode.Zs, c) && c != '\t'
-	})
-	if firstNonSpace < 0 {
-		return line
+	rel, err := filepath.Rel(outputDir, templateFile)
+	if err != nil {
+		return "", err
 	}
-	return line[:firstNonSpace]
-}
-
-// isSpace reports whether all the characters in s are whitespace characters.
-func isSpace(s string) bool {
-	return !strings.ContainsFunc(s, isNonSpace)
-}
-
-func isNonSpace(c rune) bool {
-	return !unicode.IsSpace(c)
+	return filepath.ToSlash(rel), nil
 }
@@ -11,6 +11,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/kagisearch/mustache-codegen/internal/syntax"
 )
 
 var suiteNames = []string{
@@ -114,6 +116,6 @@ func FuzzParse(f *testing.F) {
 
 	f.Fuzz(func(t *testing.T, s string) {
 		// Testing to see if parse panics or infinite loops.
-		parse(s)
+		syntax.Parse(s)
 	})
 }
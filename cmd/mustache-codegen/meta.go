@@ -0,0 +1,115 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kagisearch/mustache-codegen/internal/syntax"
+)
+
+// templateMeta returns the fields of the template's front matter.
+// ok is false if the template has no front matter.
+func templateMeta(tags []syntax.Tag) (fields []syntax.MetaField, ok bool, err error) {
+	for _, t := range tags {
+		if t.Type == syntax.FrontMatter {
+			// Parse has checked that the front matter is valid.
+			fields, err := syntax.ParseFrontMatter(t.S)
+			return fields, true, err
+		}
+	}
+	return nil, false, nil
+}
+
+// writeGoMeta writes the declaration of a variable with the given name
+// that holds the template's front matter as a struct
+// with a field for each key, named in camel case.
+func writeGoMeta(buf *bytes.Buffer, name, funcName string, fields []syntax.MetaField) error {
+	fieldNames := make([]string, len(fields))
+	types := make([]string, len(fields))
+	literals := make([]string, len(fields))
+	for i, f := range fields {
+		var err error
+		types[i], literals[i], err = goMetaValue(f.Value)
+		if err != nil {
+			return fmt.Errorf("front matter value %s: %v", f.Key, err)
+		}
+		fieldNames[i] = goFuncName(f.Key, true)
+		for j := range i {
+			if fieldNames[j] == fieldNames[i] {
+				return fmt.Errorf("front matter keys %s and %s have the same Go name %s", fields[j].Key, f.Key, fieldNames[i])
+			}
+		}
+	}
+
+	fmt.Fprintf(buf, "\n// %s is the front matter of the template rendered by %s.\n", name, funcName)
+	fmt.Fprintf(buf, "var %s = struct {\n", name)
+	for i := range fields {
+		fmt.Fprintf(buf, "\t%s %s\n", fieldNames[i], types[i])
+	}
+	fmt.Fprintln(buf, "}{")
+	for i := range fields {
+		fmt.Fprintf(buf, "\t%s: %s,\n", fieldNames[i], literals[i])
+	}
+	fmt.Fprintln(buf, "}")
+	return nil
+}
+
+// goMetaValue returns the Go type and literal of a front matter value.
+// Integers that fit in an int64 are ints and other numbers are float64s.
+// It returns an error for numbers that do not fit in a float64.
+func goMetaValue(v any) (typ, literal string, err error) {
+	switch v := v.(type) {
+	case string:
+		return "string", strconv.Quote(v), nil
+	case bool:
+		return "bool", strconv.FormatBool(v), nil
+	case json.Number:
+		if _, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return "int", v.String(), nil
+		}
+		if _, err := strconv.ParseFloat(v.String(), 64); errors.Is(err, strconv.ErrRange) {
+			return "", "", fmt.Errorf("%s is out of range for float64", v)
+		}
+		return "float64", v.String(), nil
+	default:
+		panic(fmt.Sprintf("unexpected front matter value %T", v))
+	}
+}
+
+// jsMetaLiteral returns a JavaScript object literal of the template's front matter.
+func jsMetaLiteral(fields []syntax.MetaField) string {
+	sb := new(strings.Builder)
+	sb.WriteString("{")
+	for i, f := range fields {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		if isJSIdentifier(f.Key) {
+			sb.WriteString(f.Key)
+		} else {
+			sb.WriteString(jsMetaValue(f.Key))
+		}
+		sb.WriteString(":")
+		sb.WriteString(jsMetaValue(f.Value))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// jsMetaValue returns the JavaScript literal of a front matter value.
+func jsMetaValue(v any) string {
+	// JSON strings are not valid JavaScript if they contain U+2028 or U+2029,
+	// but json.Marshal escapes them.
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
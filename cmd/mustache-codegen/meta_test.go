@@ -0,0 +1,171 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const metaTemplate = "---\ntitle: Home\ncache_ttl: 300\ndraft: false\nratio: 0.5\n---\nHello, {{subject}}!\n"
+
+func TestCompileGoMeta(t *testing.T) {
+	load := func(name string) (string, error) { return "", nil }
+	tests := []struct {
+		name string
+		opts goOptions
+		want []string
+	}{
+		{
+			name: "Default",
+			opts: goOptions{packageName: "foo", frontMatter: true},
+			want: []string{
+				"// PageMeta is the front matter of the template rendered by Page.\n",
+				"var PageMeta = struct {\n\tTitle    string\n\tCacheTtl int\n\tDraft    bool\n\tRatio    float64\n}{\n",
+				"\tTitle:    \"Home\",\n\tCacheTtl: 300,\n\tDraft:    false,\n\tRatio:    0.5,\n",
+				"const PageSizeHint = 9\n",
+			},
+		},
+		{
+			name: "Receiver",
+			opts: goOptions{packageName: "foo", frontMatter: true, receiver: "*Templates"},
+			want: []string{"var TemplatesPageMeta = struct {"},
+		},
+		{
+			name: "Interpret",
+			opts: goOptions{packageName: "foo", frontMatter: true, interpret: true, templatePath: "page.mustache"},
+			want: []string{"var PageMeta = struct {", "FrontMatter: true,"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := compileGo("page", metaTemplate, load, &test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.want {
+				if !bytes.Contains(got, []byte(want)) {
+					t.Errorf("generated code does not contain %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+
+	got, err := compileGo("page", "Hello\n", load, &goOptions{packageName: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(got, []byte("Meta")) {
+		t.Errorf("generated code for a template without front matter declares metadata:\n%s", got)
+	}
+	got, err = compileGo("page", metaTemplate, load, &goOptions{packageName: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(got, []byte("Meta")) || !bytes.Contains(got, []byte("title: Home")) {
+		t.Errorf("generated code without -front-matter does not render the front matter as text:\n%s", got)
+	}
+	if _, err := compileGo("page", "---\nfoo_bar: 1\nfooBar: 2\n---\n", load, &goOptions{packageName: "foo", frontMatter: true}); err == nil {
+		t.Error("compileGo did not return an error for front matter keys with the same Go name")
+	}
+	for _, source := range []string{"---\nn: 1e400\n---\n", "---\n{\"n\": -1e400}\n---\n"} {
+		_, err := compileGo("page", source, load, &goOptions{packageName: "foo", frontMatter: true})
+		if err == nil || !strings.Contains(err.Error(), "out of range") {
+			t.Errorf("compileGo(%q) error = %v; want out of range", source, err)
+		}
+	}
+	// Numbers that only lose precision are fine.
+	if _, err := compileGo("page", "---\nn: 1e-400\n---\n", load, &goOptions{packageName: "foo", frontMatter: true}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCompileJSMeta(t *testing.T) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Cannot find node:", err)
+	}
+	load := func(name string) (string, error) { return "", nil }
+	const want = `{"title":"Home","cache_ttl":300,"draft":false,"ratio":0.5}`
+
+	tests := []struct {
+		name   string
+		opts   jsOptions
+		file   string
+		script string
+	}{
+		{
+			name:   "ESM",
+			opts:   jsOptions{frontMatter: true},
+			file:   "template.mjs",
+			script: `import t, {meta} from './template.mjs'; process.stdout.write(JSON.stringify(meta))`,
+		},
+		{
+			name:   "ESMName",
+			opts:   jsOptions{name: "page", pretty: true, frontMatter: true},
+			file:   "template.mjs",
+			script: `import {meta} from './template.mjs'; process.stdout.write(JSON.stringify(meta))`,
+		},
+		{
+			name:   "CJS",
+			opts:   jsOptions{format: "cjs", frontMatter: true},
+			file:   "template.cjs",
+			script: `const t = require('./template.cjs'); process.stdout.write(JSON.stringify(t.meta))`,
+		},
+		{
+			name:   "IIFE",
+			opts:   jsOptions{format: "iife", frontMatter: true},
+			file:   "template.js",
+			script: `eval(require('fs').readFileSync('template.js', 'utf8') + '; globalThis.page = page'); process.stdout.write(JSON.stringify(page.meta))`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			js, err := compileJS("page", metaTemplate, load, &test.opts)
+			if err != nil {
+				t.Fatal("compile:", err)
+			}
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, test.file), js, 0o666); err != nil {
+				t.Fatal(err)
+			}
+			inputType := "commonjs"
+			if filepath.Ext(test.file) == ".mjs" {
+				inputType = "module"
+			}
+			c := exec.Command(nodePath, "--input-type="+inputType, "-e", test.script)
+			c.Dir = dir
+			c.Stderr = os.Stderr
+			out, err := c.Output()
+			if err != nil {
+				t.Fatalf("error: %v\ngenerated code:\n%s", err, generatedJS(js))
+			}
+			if got := string(out); got != want {
+				t.Errorf("meta = %s; want %s", got, want)
+			}
+		})
+	}
+
+	t.Run("Bundle", func(t *testing.T) {
+		templates := []jsTemplate{
+			{name: "home", source: metaTemplate, load: load},
+			{name: "footer", source: "Bye\n", load: load},
+		}
+		js, err := compileJSBundle(templates, &jsOptions{frontMatter: true})
+		if err != nil {
+			t.Fatal("compile:", err)
+		}
+		if !bytes.Contains(js, []byte("export const homeMeta={")) || bytes.Contains(js, []byte("footerMeta")) {
+			t.Errorf("bundle does not export homeMeta alone:\n%s", generatedJS(js))
+		}
+	})
+
+	if _, err := compileJS("page", metaTemplate, load, &jsOptions{name: "meta", frontMatter: true}); err == nil {
+		t.Error("compileJS did not return an error for the name meta")
+	}
+}
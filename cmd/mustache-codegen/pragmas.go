@@ -0,0 +1,104 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"go/parser"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/kagisearch/mustache-codegen/go/mustache"
+	"github.com/kagisearch/mustache-codegen/internal/syntax"
+)
+
+// goPragmaOptions returns opts with the pragmas declared by the template applied.
+// opts is returned as is if the template has no pragmas.
+func goPragmaOptions(tags []syntax.Tag, opts *goOptions) (*goOptions, error) {
+	pragmas, err := syntax.Pragmas(tags)
+	if err != nil || len(pragmas) == 0 {
+		return opts, err
+	}
+	newOpts := *opts
+	for name, value := range pragmas {
+		switch name {
+		case "ESCAPE":
+			e, ok := mustache.ParseEscaper(value)
+			if !ok {
+				return nil, fmt.Errorf("unknown escaper %q in pragma %s", value, name)
+			}
+			newOpts.escaper = e
+		case "GO_TYPE":
+			newOpts.dataType = value
+		case "STRICT":
+			newOpts.strict = true
+		}
+	}
+	return &newOpts, nil
+}
+
+// jsPragmaOptions returns opts with the pragmas declared by the template applied.
+// opts is returned as is if the template has no pragmas.
+// Pragmas for other languages are ignored.
+func jsPragmaOptions(tags []syntax.Tag, opts *jsOptions) (*jsOptions, error) {
+	pragmas, err := syntax.Pragmas(tags)
+	if err != nil || len(pragmas) == 0 {
+		return opts, err
+	}
+	newOpts := *opts
+	for name, value := range pragmas {
+		switch name {
+		case "ESCAPE":
+			e, ok := mustache.ParseEscaper(value)
+			if !ok {
+				return nil, fmt.Errorf("unknown escaper %q in pragma %s", value, name)
+			}
+			newOpts.escaper = e
+		case "STRICT":
+			newOpts.strict = true
+		}
+	}
+	return &newOpts, nil
+}
+
+// goMajorVersion matches the major version suffix of a module path.
+var goMajorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// goDataType splits the type of a generated function's data parameter,
+// like "*example.com/app/views.Page", into the path of the package to import
+// and the type expression that refers to it by the package's name ("*views.Page").
+// A type in the generated file's package, like "Page" or "main.Page",
+// or a type that needs no import, like "map[string]any", has no import path.
+// An empty dataType is returned as is.
+func goDataType(dataType, packageName string) (importPath, typeExpr string, err error) {
+	if dataType == "" {
+		return "", "", nil
+	}
+	rest := strings.TrimLeft(dataType, "*[]")
+	prefix := dataType[:len(dataType)-len(rest)]
+	typeExpr = dataType
+	if dot := strings.LastIndex(rest, "."); dot >= 0 && !strings.ContainsAny(rest[:dot], "[]{}() ") {
+		importPath = rest[:dot]
+		name := path.Base(importPath)
+		if goMajorVersion.MatchString(name) && strings.Contains(importPath, "/") {
+			name = path.Base(path.Dir(importPath))
+		}
+		name = strings.Map(func(c rune) rune {
+			if c == '-' || c == '.' {
+				return '_'
+			}
+			return c
+		}, name)
+		typeExpr = prefix + name + rest[dot:]
+		if importPath == packageName {
+			importPath = ""
+			typeExpr = prefix + rest[dot+1:]
+		}
+	}
+	if _, err := parser.ParseExpr(typeExpr); err != nil {
+		return "", "", fmt.Errorf("invalid Go type %q", dataType)
+	}
+	return importPath, typeExpr, nil
+}
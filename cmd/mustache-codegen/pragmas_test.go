@@ -0,0 +1,192 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGoDataType(t *testing.T) {
+	tests := []struct {
+		dataType   string
+		importPath string
+		typeExpr   string
+	}{
+		{"Page", "", "Page"},
+		{"main.Page", "", "Page"},
+		{"*main.Page", "", "*Page"},
+		{"map[string]any", "", "map[string]any"},
+		{"time.Time", "time", "time.Time"},
+		{"*example.com/app/views.Page", "example.com/app/views", "*views.Page"},
+		{"[]example.com/app/views.Item", "example.com/app/views", "[]views.Item"},
+		{"example.com/app/v2.Page", "example.com/app/v2", "app.Page"},
+		{"example.com/go-views.Page", "example.com/go-views", "go_views.Page"},
+	}
+	for _, test := range tests {
+		importPath, typeExpr, err := goDataType(test.dataType, "main")
+		if err != nil || importPath != test.importPath || typeExpr != test.typeExpr {
+			t.Errorf("goDataType(%q, \"main\") = %q, %q, %v; want %q, %q, <nil>",
+				test.dataType, importPath, typeExpr, err, test.importPath, test.typeExpr)
+		}
+	}
+
+	for _, dataType := range []string{"views.", "*", "a b"} {
+		if _, _, err := goDataType(dataType, "main"); err == nil {
+			t.Errorf("goDataType(%q, \"main\") did not return an error", dataType)
+		}
+	}
+}
+
+func TestCompileGoPragmas(t *testing.T) {
+	load := func(name string) (string, error) { return "{{punctuation}}", nil }
+	tests := []struct {
+		name   string
+		source string
+		opts   goOptions
+		want   []string
+	}{
+		{
+			name:   "GoType",
+			source: "{{%GO_TYPE=*example.com/app/views.Page}}\nHello, {{subject}}{{>punctuation}}\n",
+			opts:   goOptions{packageName: "foo"},
+			want: []string{
+				"\tviews \"example.com/app/views\"\n",
+				"func Page(buf *bytes.Buffer, data *views.Page) {",
+			},
+		},
+		{
+			name:   "GoTypeInterpret",
+			source: "{{%GO_TYPE=*example.com/app/views.Page}}\nHello, {{subject}}{{>punctuation}}\n",
+			opts:   goOptions{packageName: "foo", interpret: true, templatePath: "page.mustache"},
+			want: []string{
+				"\tviews \"example.com/app/views\"\n",
+				"func Page(buf *bytes.Buffer, data *views.Page) {",
+			},
+		},
+		{
+			name:   "Strict",
+			source: "{{%STRICT}}\nHello, {{subject}}{{.}}{{>punctuation}}\n",
+			opts:   goOptions{packageName: "foo"},
+			want: []string{
+				`m.EscapeQuotes.Escape(buf, m.ToString(m.Strict(stack.Lookup("subject"), "subject")))`,
+				`m.EscapeQuotes.Escape(buf, m.ToString(stack.Top()))`,
+				`m.EscapeQuotes.Escape(buf, m.ToString(m.Strict(stack.Lookup("punctuation"), "punctuation")))`,
+			},
+		},
+		{
+			name:   "EscapeNone",
+			source: "{{%ESCAPE=none}}\nHello, {{subject}}\n",
+			opts:   goOptions{packageName: "foo"},
+			want:   []string{`m.EscapeNone.Escape(buf, m.ToString(stack.Lookup("subject")))`},
+		},
+		{
+			name:   "Flags",
+			source: "Hello, {{subject}}\n",
+			opts:   goOptions{packageName: "foo", strict: true, dataType: "Page"},
+			want: []string{
+				"func Page(buf *bytes.Buffer, data Page) {",
+				`m.Strict(stack.Lookup("subject"), "subject")`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := compileGo("page", test.source, load, &test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range test.want {
+				if !bytes.Contains(got, []byte(want)) {
+					t.Errorf("generated code does not contain %q:\n%s", want, got)
+				}
+			}
+		})
+	}
+
+	badSources := []string{
+		"{{%FOO}}",
+		"{{%ESCAPE=html}}",
+		"{{%GO_TYPE=a b}}",
+		"{{#a}}{{%STRICT}}{{/a}}",
+	}
+	for _, source := range badSources {
+		if _, err := compileGo("page", source, load, &goOptions{packageName: "foo"}); err == nil {
+			t.Errorf("compileGo(%q) did not return an error", source)
+		}
+	}
+	badPartial := func(name string) (string, error) { return "{{%BOGUS}}", nil }
+	if _, err := compileGo("page", "{{>part}}", badPartial, &goOptions{packageName: "foo"}); err == nil {
+		t.Error("compileGo did not return an error for an unknown pragma in a partial")
+	}
+	if _, err := compileJS("page", "{{>part}}", badPartial, &jsOptions{}); err == nil {
+		t.Error("compileJS did not return an error for an unknown pragma in a partial")
+	}
+}
+
+func TestCompileJSPragmas(t *testing.T) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Cannot find node:", err)
+	}
+	load := func(name string) (string, error) { return "{{punctuation}}", nil }
+
+	for _, mode := range jsModes {
+		t.Run(mode.name, func(t *testing.T) {
+			js, err := compileJS("template", "{{%ESCAPE=none}}\n{{%GO_TYPE=Page}}\n<{{subject}}>\n", load, &jsOptions{stream: mode.stream, pretty: mode.pretty})
+			if err != nil {
+				t.Fatal("compile:", err)
+			}
+			if got, want := runJS(t, nodePath, js, "{subject: '<b>World</b>'}", mode.stream), "<<b>World</b>>\n"; got != want {
+				t.Errorf("ESCAPE=none output = %q; want %q", got, want)
+			}
+
+			js, err = compileJS("template", "{{%STRICT}}\nHello, {{subject}}{{>punctuation}}\n", load, &jsOptions{stream: mode.stream, pretty: mode.pretty})
+			if err != nil {
+				t.Fatal("compile:", err)
+			}
+			if got, want := runJS(t, nodePath, js, "{subject: 'World', punctuation: '!'}", mode.stream), "Hello, World!\n"; got != want {
+				t.Errorf("STRICT output = %q; want %q", got, want)
+			}
+			if err := runJSError(t, nodePath, js, "{subject: 'World'}", mode.stream); !strings.Contains(err, "missing value for {{punctuation}}") {
+				t.Errorf("STRICT error = %q; want missing value for {{punctuation}}", err)
+			}
+		})
+	}
+
+	templates := []jsTemplate{
+		{name: "a", source: "{{a}}", load: load},
+		{name: "b", source: "{{%ESCAPE=none}}{{b}}", load: load},
+	}
+	if _, err := compileJSBundle(templates, &jsOptions{}); err == nil {
+		t.Error("compileJSBundle did not return an error for templates with different escapers")
+	}
+}
+
+// runJSError runs a compiled template like [runJS]
+// and returns the error message it throws.
+func runJSError(t *testing.T, nodePath string, js []byte, data string, stream bool) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "template.mjs"), js, 0o666); err != nil {
+		t.Fatal(err)
+	}
+	call := `t(` + data + `)`
+	if stream {
+		call = `(async () => { for await (const _ of t(` + data + `)); })()`
+	}
+	script := `import t from './template.mjs'; try { await ` + call + ` } catch (e) { process.stdout.write(e.message) }`
+	c := exec.Command(nodePath, "--input-type=module", "-e", script)
+	c.Dir = dir
+	c.Stderr = os.Stderr
+	out, err := c.Output()
+	if err != nil {
+		t.Fatalf("error: %v\ngenerated code:\n%s", err, generatedJS(js))
+	}
+	return string(out)
+}
@@ -1,5 +1,16 @@
-const esc=(e)=>{var a=""+e,t=match_html.exec(a);if(!t)return e;var r,c,n,s="";for(r=t.index,c=0;r<a.length;r++){switch(a.charCodeAt(r)){case 34:n="&quot;";break;case 38:n="&amp;";break;case 60:n="&lt;";break;case 62:n="&gt;";break;default:continue}c!==r&&(s+=a.substring(c,r)),c=r+1,s+=n}return c!==r?s+a.substring(c,r):s}
-const match_html = /["&<>]/
-const look=(s,k)=>{for(let i=s.length-1;i>=0;i--){if(Object.prototype.hasOwnProperty.call(s[i], k))return s[i][k]}return undefined}
+const esc=(e)=>{var a=""+e,t=match_html.exec(a);if(!t)return e;var r,c,n,s="";for(r=t.index,c=0;r<a.length;r++){switch(a.charCodeAt(r)){case 34:n="&quot;";break;case 38:n="&amp;";break;case 39:if(!esc_q)continue;n="&#39;";break;case 60:n="&lt;";break;case 62:n="&gt;";break;default:continue}c!==r&&(s+=a.substring(c,r)),c=r+1,s+=n}return c!==r?s+a.substring(c,r):s}
+const match_html = /["&'<>]/, esc_q = true
+const has=(c,k)=>{if(c==null)return false;if(typeof c!=="object"&&typeof c!=="function")return Object.prototype.hasOwnProperty.call(c,k);for(let o=c;o!==null&&o!==Object.prototype&&o!==Function.prototype;o=Object.getPrototypeOf(o))if(Object.prototype.hasOwnProperty.call(o,k))return true;return false}
+const call=(c,v)=>typeof v==="function"&&v.length===0?v.call(c):v
+const look=(s,k)=>{for(let i=s.length-1;i>=0;i--){const c=s[i];if(c instanceof Map){if(c.has(k))return call(c,c.get(k))}else if(has(c,k))return call(c,c[k])}return undefined}
+const prop=(x,k)=>x instanceof Map?call(x,x.get(k)):has(x,k)?call(x,x[k]):undefined
 const arr=Array.isArray
-const f=(x)=>!x||(arr(x)&&x.length===0)
+const it=(x)=>x!=null&&typeof x!=="string"&&typeof x[Symbol.iterator]==="function"
+const f=(x)=>{if(!x)return true;if(arr(x))return x.length===0;if(!it(x))return false;const i=x[Symbol.iterator]();return i!==x&&!!i.next().done}
+const each=(c,g)=>{if(arr(c))c.forEach(g);else if(it(c)){const m=c instanceof Map;for(const e of c)g(m?{key:e[0],value:e[1]}:e)}else g(c)}
+const aeach=async function*(c,g){if(c!=null&&typeof c[Symbol.asyncIterator]==="function"){for await(const e of c)yield* g(e)}else if(it(c)){const m=c instanceof Map;for(const e of c)yield* g(m?{key:e[0],value:await e[1]}:await e)}else yield* g(c)}
+const tpath=(s,k)=>k==="."?s.at(-1):k.split(".").reduce((x,p,i)=>i?prop(x,p):look(s,p),undefined)
+const tre=new RegExp("\\{\\{(&?)([\\s\\S]*?)\\}\\}","g")
+const i18n=(s,m)=>(s.t?s.t(m):m).replace(tre,(t,r,k)=>{const v=tpath(s,k.trim())??"";return r?v:esc(v)})
+const ai18n=async(s,m)=>{m=s.t?s.t(m):m;const v=[];for(const r of m.matchAll(tre))v.push(await tpath(s,r[2].trim()));let i=0;return m.replace(tre,(t,r)=>{const x=v[i++]??"";return r?x:esc(x)})}
+const must=(v,k)=>{if(v===undefined)throw new Error("mustache: missing value for {{"+k+"}}");return v}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/kagisearch/mustache-codegen/go/mustache"
+	"github.com/kagisearch/mustache-codegen/go/mustache/interp"
+)
+
+// renderMain runs the render command,
+// which renders a template with JSON or YAML data without generating code.
+func renderMain(args []string) {
+	fset := flag.FlagSet{Usage: func() {}}
+	dataFile := fset.String("data", "", "JSON or YAML `file` with the data to render the template with, or - for standard input (default no data)")
+	dataFormat := fset.String("data-format", "", "`format` of the data: json or yaml (default yaml for .yaml and .yml files and json otherwise)")
+	opts := new(interp.RenderOptions)
+	fset.Func("escape", "`set` of characters to escape in variables: quotes (&<>\"', the default), minimal (&<>\"), or none", func(s string) error {
+		e, ok := mustache.ParseEscaper(s)
+		if !ok {
+			return fmt.Errorf("unknown escaper %q", s)
+		}
+		opts.Escaper = e
+		return nil
+	})
+	fset.BoolVar(&opts.Strict, "strict", false, "make variables that cannot be found errors")
+	fset.BoolVar(&opts.MinifyHTML, "minify-html", false, "collapse whitespace and remove comments in the template's HTML")
+	fset.BoolVar(&opts.FrontMatter, "front-matter", false, "parse a block between two --- lines at the start of the template as metadata instead of text")
+	outputFile := fset.String("o", "", "output `file`")
+	if err := fset.Parse(args); err != nil || fset.NArg() != 1 {
+		fmt.Fprintf(fset.Output(), "usage: %s render [options] TEMPLATE\n\n", programName)
+		fset.PrintDefaults()
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		os.Exit(64) // EX_USAGE
+	}
+
+	var data []byte
+	var err error
+	switch *dataFile {
+	case "":
+	case "-":
+		data, err = io.ReadAll(os.Stdin)
+	default:
+		data, err = os.ReadFile(*dataFile)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
+		os.Exit(1)
+	}
+	format := *dataFormat
+	if format == "" {
+		format = "json"
+		if ext := filepath.Ext(*dataFile); ext == ".yaml" || ext == ".yml" {
+			format = "yaml"
+		}
+	}
+	output, err := renderTemplate(fset.Arg(0), data, format, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
+		os.Exit(1)
+	}
+	writeOutput(*outputFile, output)
+}
+
+// stringKeys returns v with the mappings in it converted to map[string]any,
+// which names are looked up in like they are in JSON objects.
+// YAML decodes mappings with keys other than strings, like 404 or true,
+// to map[any]any; their keys are converted to their text.
+// It returns an error for mappings with keys that are not scalars
+// or that have the same text as another key.
+func stringKeys(v any) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, elem := range v {
+			elem, err := stringKeys(elem)
+			if err != nil {
+				return nil, err
+			}
+			v[k] = elem
+		}
+		return v, nil
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, elem := range v {
+			switch k.(type) {
+			case string, int, int64, uint64, float64, bool:
+			default:
+				return nil, fmt.Errorf("mapping key %v is not a string, number, or boolean", k)
+			}
+			key := fmt.Sprint(k)
+			if _, ok := m[key]; ok {
+				return nil, fmt.Errorf("mapping has more than one key %s", key)
+			}
+			elem, err := stringKeys(elem)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = elem
+		}
+		return m, nil
+	case []any:
+		for i, elem := range v {
+			elem, err := stringKeys(elem)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = elem
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// renderTemplate renders the template in the named file with data
+// in the given format ("json" or "yaml")
+// using the interpreter, which has the same semantics as the generated Go code.
+// The template's pragmas take precedence over opts as they do when generating code.
+// Empty data renders the template with no data.
+// A variable that cannot be found in a strict template is an error.
+func renderTemplate(fname string, data []byte, format string, opts *interp.RenderOptions) (output []byte, err error) {
+	var value any
+	if len(bytes.TrimSpace(data)) > 0 {
+		switch format {
+		case "json":
+			err = json.Unmarshal(data, &value)
+		case "yaml":
+			if err = yaml.Unmarshal(data, &value); err == nil {
+				value, err = stringKeys(value)
+			}
+		default:
+			return nil, fmt.Errorf("unknown data format %q", format)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("data: %v", err)
+		}
+	}
+	defer func() {
+		switch e := recover().(type) {
+		case nil:
+		case *mustache.MissingError:
+			output, err = nil, fmt.Errorf("%s: %v", fname, e)
+		default:
+			panic(e)
+		}
+	}()
+	buf := new(bytes.Buffer)
+	if err := interp.RenderFileOptions(buf, fname, value, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kagisearch/mustache-codegen/go/mustache"
+	"github.com/kagisearch/mustache-codegen/go/mustache/interp"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"page.mustache":   "{{>header}}{{#items}}<li>{{name}}</li>{{/items}}{{^items}}none{{/items}}\n",
+		"header.mustache": "<h1>{{title}}</h1>\n",
+		"quoted.mustache": "{{%ESCAPE=quotes}}{{s}}",
+		"title.mustache":  "<a title='{{x}}'>",
+		"status.mustache": "{{#pages}}{{code.404}}: {{code.true}} {{#links}}{{1}}{{/links}};{{/pages}}",
+		"strict.mustache": "{{%STRICT}}Hello {{name}}",
+		"filter.mustache": "{{price|currency}}",
+		"list.mustache":   "<ul>  <!-- items -->\n  <li>{{x}}</li>\n</ul>\n",
+		"meta.mustache":   "---\ntitle: Home\n---\n# {{title}}\n",
+		"notes.mustache":  "---\n- a\n---\n- {{b}}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		name     string
+		template string
+		data     string
+		format   string
+		opts     interp.RenderOptions
+		want     string
+	}{
+		{
+			name:     "Data",
+			template: "page.mustache",
+			data:     `{"title": "A & B", "items": [{"name": "x"}, {"name": 2.5}]}`,
+			want:     "<h1>A &amp; B</h1>\n<li>x</li><li>2.5</li>\n",
+		},
+		{
+			name:     "YAML",
+			template: "page.mustache",
+			data:     "title: A & B\nitems:\n  - name: x\n  - name: 2.5\n",
+			format:   "yaml",
+			want:     "<h1>A &amp; B</h1>\n<li>x</li><li>2.5</li>\n",
+		},
+		{
+			name:     "YAMLKeys",
+			template: "status.mustache",
+			data:     "pages:\n  - code:\n      404: Not found\n      true: yes\n    links:\n      - 1: a\n      - 1: b\n",
+			format:   "yaml",
+			want:     "Not found: yes ab;",
+		},
+		{
+			name:     "NoData",
+			template: "page.mustache",
+			want:     "<h1></h1>\nnone\n",
+		},
+		{
+			name:     "Escaper",
+			template: "page.mustache",
+			data:     `{"title": "A & B"}`,
+			opts:     interp.RenderOptions{Escaper: mustache.EscapeNone},
+			want:     "<h1>A & B</h1>\nnone\n",
+		},
+		{
+			name:     "DefaultEscaper",
+			template: "title.mustache",
+			data:     `{"x": "'onmouseover=alert(1) '"}`,
+			want:     "<a title='&#39;onmouseover=alert(1) &#39;'>",
+		},
+		{
+			name:     "Pragma",
+			template: "quoted.mustache",
+			data:     `{"s": "it's"}`,
+			opts:     interp.RenderOptions{Escaper: mustache.EscapeNone},
+			want:     "it&#39;s",
+		},
+		{
+			name:     "Strict",
+			template: "strict.mustache",
+			data:     `{"name": "World"}`,
+			want:     "Hello World",
+		},
+		{
+			name:     "MinifyHTML",
+			template: "list.mustache",
+			data:     `{"x": "a  b"}`,
+			opts:     interp.RenderOptions{MinifyHTML: true},
+			want:     "<ul> \n<li>a  b</li>\n</ul>\n",
+		},
+		{
+			name:     "NoFilters",
+			template: "filter.mustache",
+			data:     `{"price": 1, "price|currency": "$1.00"}`,
+			want:     "$1.00",
+		},
+		{
+			name:     "FrontMatter",
+			template: "meta.mustache",
+			data:     `{"title": "Notes"}`,
+			opts:     interp.RenderOptions{FrontMatter: true},
+			want:     "# Notes\n",
+		},
+		{
+			name:     "NoFrontMatter",
+			template: "notes.mustache",
+			data:     `{"b": "it's"}`,
+			opts:     interp.RenderOptions{Escaper: mustache.EscapeNone},
+			want:     "---\n- a\n---\n- it's\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			format := test.format
+			if format == "" {
+				format = "json"
+			}
+			got, err := renderTemplate(filepath.Join(dir, test.template), []byte(test.data), format, &test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.want {
+				t.Errorf("renderTemplate(...) = %q; want %q", got, test.want)
+			}
+		})
+	}
+
+	badRenders := []struct {
+		template string
+		data     string
+		format   string
+		opts     interp.RenderOptions
+	}{
+		{template: "page.mustache", data: "{"},
+		{template: "page.mustache", data: "title: [", format: "yaml"},
+		{template: "page.mustache", data: "{}", format: "toml"},
+		{template: "page.mustache", data: "title:\n  1: a\n  \"1\": b\n", format: "yaml"},
+		{template: "page.mustache", data: "title:\n  ? [a]\n  : b\n", format: "yaml"},
+		{template: "strict.mustache", data: "{}"},
+		{template: "page.mustache", data: "{}", opts: interp.RenderOptions{Strict: true}},
+		{template: "notes.mustache", data: "{}", opts: interp.RenderOptions{FrontMatter: true}},
+	}
+	for _, test := range badRenders {
+		format := test.format
+		if format == "" {
+			format = "json"
+		}
+		if _, err := renderTemplate(filepath.Join(dir, test.template), []byte(test.data), format, &test.opts); err == nil {
+			t.Errorf("renderTemplate(%q, %q, %q, %+v) did not return an error", test.template, test.data, format, test.opts)
+		}
+	}
+}
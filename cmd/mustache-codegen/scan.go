@@ -0,0 +1,81 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import "strings"
+
+// scannedTag is a tag found by [scanTags].
+type scannedTag struct {
+	// sigil is the tag's type character, like '#' or '>',
+	// or 0 for variables.
+	// Triple mustaches are '&'.
+	sigil byte
+	// name is the tag's name without its filters.
+	name string
+	// text is the text between the tag's delimiters after its sigil,
+	// or the delimiters a set delimiter tag changes to.
+	text string
+	// start and end are the offsets of the tag including its delimiters.
+	start, end int
+	// unclosed is whether the text ends before the tag's end delimiter.
+	unclosed bool
+}
+
+// scanTags returns the tags in a template's text with their positions,
+// following changes of delimiters.
+// Unlike [syntax.Parse], it does not check that the template is valid,
+// so that it can be used by the language server while a template is being edited
+// and by the formatter to rewrite tags in place.
+// If the text ends in the middle of a tag, the last tag is unclosed.
+func scanTags(text string) []scannedTag {
+	var tags []scannedTag
+	startDelim, endDelim := "{{", "}}"
+	for i := 0; ; {
+		j := strings.Index(text[i:], startDelim)
+		if j < 0 {
+			return tags
+		}
+		t := scannedTag{start: i + j}
+		innerStart := t.start + len(startDelim)
+		closeDelim := endDelim
+		triple := startDelim == "{{" && endDelim == "}}" && strings.HasPrefix(text[innerStart:], "{")
+		if triple {
+			closeDelim = "}" + endDelim
+		}
+		k := strings.Index(text[innerStart:], closeDelim)
+		if k < 0 {
+			t.unclosed = true
+			k = len(text) - innerStart
+			t.end = len(text)
+		} else {
+			t.end = innerStart + k + len(closeDelim)
+		}
+		inner := text[innerStart : innerStart+k]
+		switch {
+		case triple:
+			t.sigil, inner = '&', inner[1:]
+		case strings.HasPrefix(inner, "=") && strings.HasSuffix(inner, "=") && len(inner) > 1:
+			t.sigil = '='
+			t.text = inner[1 : len(inner)-1]
+			if delims := strings.Fields(t.text); len(delims) == 2 && !t.unclosed {
+				startDelim, endDelim = delims[0], delims[1]
+			}
+			inner = ""
+		case inner != "" && strings.IndexByte("#^/<>$&!%=", inner[0]) >= 0:
+			t.sigil, inner = inner[0], inner[1:]
+		}
+		if t.sigil != '=' {
+			t.text = inner
+		}
+		if t.sigil == 0 || t.sigil == '&' {
+			inner, _, _ = strings.Cut(inner, "|")
+		}
+		t.name = strings.TrimSpace(inner)
+		tags = append(tags, t)
+		if t.unclosed {
+			return tags
+		}
+		i = t.end
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import "testing"
+
+func TestScanTags(t *testing.T) {
+	tags := scanTags("a {{#s}}{{{raw}}}{{=<% %>=}}<% x | f %><%/s%> {{<%$y")
+	want := []scannedTag{
+		{sigil: '#', name: "s", text: "s", start: 2, end: 8},
+		{sigil: '&', name: "raw", text: "raw", start: 8, end: 17},
+		{sigil: '=', text: "<% %>", start: 17, end: 28},
+		{name: "x", text: " x | f ", start: 28, end: 39},
+		{sigil: '/', name: "s", text: "s", start: 39, end: 45},
+		{sigil: '$', name: "y", text: "y", start: 48, end: 52, unclosed: true},
+	}
+	if len(tags) != len(want) {
+		t.Fatalf("scanTags(...) = %+v; want %+v", tags, want)
+	}
+	for i := range want {
+		if tags[i] != want[i] {
+			t.Errorf("tags[%d] = %+v; want %+v", i, tags[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,433 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// specFiles is the vendored Mustache specification.
+//
+//go:embed testdata/*.json
+var specFiles embed.FS
+
+// specSuite is a file of the Mustache specification.
+// Optional modules have names that start with "~".
+type specSuite struct {
+	name  string
+	tests []*specTest
+}
+
+type specTest struct {
+	Name     string
+	Desc     string
+	Data     json.RawMessage
+	Template string
+	Partials map[string]string
+	Expected string
+}
+
+// specResult is the output of a spec test rendered by a backend.
+type specResult struct {
+	output string
+	err    error
+}
+
+// specBackend renders spec tests with the code generated for a -lang.
+type specBackend struct {
+	name string
+	// run renders every test and returns the results in the same order.
+	run func(tests []*specTest) ([]specResult, error)
+}
+
+// specMain runs the spec command,
+// which reports which tests of the Mustache specification each backend passes.
+func specMain(args []string) {
+	fset := flag.FlagSet{Usage: func() {}}
+	langs := fset.String("lang", "go,js", "comma-separated `languages` to test")
+	goModule := fset.String("go-module", "", "`directory` of the mustache-codegen module that generated Go code is built with (default the version of this program)")
+	verbose := fset.Bool("v", false, "print the output of failing tests")
+	if err := fset.Parse(args); err != nil || fset.NArg() > 0 {
+		fmt.Fprintf(fset.Output(), "usage: %s spec [options]\n\n", programName)
+		fset.PrintDefaults()
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		os.Exit(64) // EX_USAGE
+	}
+
+	var backends []specBackend
+	for _, lang := range strings.Split(*langs, ",") {
+		switch lang {
+		case "go":
+			backends = append(backends, specBackend{name: "go", run: func(tests []*specTest) ([]specResult, error) {
+				return runGoSpecs(tests, *goModule)
+			}})
+		case "js":
+			backends = append(backends, specBackend{name: "js", run: runJSSpecs})
+		default:
+			fmt.Fprintf(os.Stderr, "%s: unknown -lang=%s\n", programName, lang)
+			os.Exit(64) // EX_USAGE
+		}
+	}
+	suites, err := loadSpecSuites()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
+		os.Exit(1)
+	}
+	if err := writeSpecMatrix(os.Stdout, suites, backends, *verbose); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", programName, err)
+		os.Exit(1)
+	}
+}
+
+// loadSpecSuites returns every suite of the vendored specification
+// ordered by name.
+func loadSpecSuites() ([]*specSuite, error) {
+	names, err := fs.Glob(specFiles, "testdata/*.json")
+	if err != nil {
+		return nil, err
+	}
+	var suites []*specSuite
+	for _, name := range names {
+		data, err := specFiles.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		var file struct{ Tests []*specTest }
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		suites = append(suites, &specSuite{
+			name:  strings.TrimSuffix(path.Base(name), ".json"),
+			tests: file.Tests,
+		})
+	}
+	return suites, nil
+}
+
+// writeSpecMatrix runs every test of the suites against the backends
+// and writes a table of the results followed by the number of tests
+// each backend passes in each suite.
+// A backend that cannot run at all is reported and left out of the table.
+func writeSpecMatrix(w io.Writer, suites []*specSuite, backends []specBackend, verbose bool) error {
+	var tests []*specTest
+	for _, suite := range suites {
+		tests = append(tests, suite.tests...)
+	}
+	var names []string
+	var results [][]specResult
+	for _, b := range backends {
+		r, err := b.run(tests)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s: %v\n", programName, b.name, err)
+			continue
+		}
+		names = append(names, b.name)
+		results = append(results, r)
+	}
+	if len(names) == 0 {
+		return errors.New("no backends could run")
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "SUITE\tTEST\t%s\n", strings.Join(names, "\t"))
+	var failures []string
+	passed := make([][]int, len(suites))
+	i := 0
+	for si, suite := range suites {
+		passed[si] = make([]int, len(names))
+		for _, test := range suite.tests {
+			fmt.Fprintf(tw, "%s\t%s", suite.name, test.Name)
+			for bi, name := range names {
+				r := results[bi][i]
+				if r.err == nil && r.output == test.Expected {
+					passed[si][bi]++
+					fmt.Fprint(tw, "\tpass")
+					continue
+				}
+				fmt.Fprint(tw, "\tFAIL")
+				if verbose {
+					detail := fmt.Sprintf("output %q; want %q", r.output, test.Expected)
+					if r.err != nil {
+						detail = r.err.Error()
+					}
+					failures = append(failures, fmt.Sprintf("%s/%s (%s): %s", suite.name, test.Name, name, detail))
+				}
+			}
+			fmt.Fprint(tw, "\n")
+			i++
+		}
+	}
+	fmt.Fprint(tw, "\n")
+	for si, suite := range suites {
+		fmt.Fprintf(tw, "%s\tTOTAL", suite.name)
+		for bi := range names {
+			fmt.Fprintf(tw, "\t%d/%d", passed[si][bi], len(suite.tests))
+		}
+		fmt.Fprint(tw, "\n")
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		fmt.Fprintf(w, "\n%s\n", strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// runGoSpecs renders the tests with generated Go code.
+// All tests are compiled into a single program so that it only has to be built once.
+// The program is built against the mustache-codegen module in moduleDir,
+// or against the version of the module this program was built from if moduleDir is empty.
+func runGoSpecs(tests []*specTest, moduleDir string) ([]specResult, error) {
+	goPath, err := exec.LookPath("go")
+	if err != nil {
+		return nil, err
+	}
+	goMod := "module specrunner\n"
+	if moduleDir != "" {
+		moduleDir, err = filepath.Abs(moduleDir)
+		if err != nil {
+			return nil, err
+		}
+		goMod += "require github.com/kagisearch/mustache-codegen v0.0.0\n" +
+			"replace github.com/kagisearch/mustache-codegen => " + moduleDir + "\n"
+	} else {
+		info, ok := debug.ReadBuildInfo()
+		if !ok || info.Main.Version == "" || info.Main.Version == "(devel)" || strings.HasSuffix(info.Main.Version, "+dirty") {
+			return nil, errors.New("cannot determine the version of the Go runtime; use -go-module")
+		}
+		goMod += "require github.com/kagisearch/mustache-codegen " + info.Main.Version + "\n"
+	}
+	dir, err := os.MkdirTemp("", "mustache-spec-go")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o666); err != nil {
+		return nil, err
+	}
+
+	results := make([]specResult, len(tests))
+	// compiled is the list of indices of the tests in the program.
+	var compiled []int
+	goMain := new(bytes.Buffer)
+	goMain.WriteString("package main\n\n" +
+		"import (\n\t\"bytes\"\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"os\"\n)\n\n" +
+		"type result struct {\n\tOutput string `json:\"output\"`\n\tError  string `json:\"error,omitempty\"`\n}\n\n" +
+		"func run(t func(*bytes.Buffer, any), data any) (r result) {\n" +
+		"\tdefer func() {\n\t\tif e := recover(); e != nil {\n\t\t\tr.Error = fmt.Sprint(e)\n\t\t}\n\t}()\n" +
+		"\tbuf := new(bytes.Buffer)\n\tt(buf, data)\n\tr.Output = buf.String()\n\treturn r\n}\n\n" +
+		"func main() {\n\tjson.NewEncoder(os.Stdout).Encode([]result{\n")
+	for i, test := range tests {
+		var data any
+		if err := json.Unmarshal(test.Data, &data); err != nil {
+			results[i].err = err
+			continue
+		}
+		funcName := fmt.Sprintf("T%d", i)
+		goSource, err := compileGo(funcName, test.Template, specPartials(test), &goOptions{packageName: "main", funcName: funcName})
+		if err != nil {
+			results[i].err = err
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("t%d.go", i)), goSource, 0o666); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(goMain, "\t\trun(%s, %s),\n", funcName, goSpecData(data))
+		compiled = append(compiled, i)
+	}
+	goMain.WriteString("\t})\n}\n")
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), goMain.Bytes(), 0o666); err != nil {
+		return nil, err
+	}
+
+	for _, args := range [][]string{{"mod", "tidy"}, {"build", "-o", "specrunner"}} {
+		c := exec.Command(goPath, args...)
+		c.Dir = dir
+		if out, err := c.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("go %s: %v\n%s", args[0], err, out)
+		}
+	}
+	c := exec.Command(filepath.Join(dir, "specrunner"))
+	c.Stderr = os.Stderr
+	out, err := c.Output()
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeSpecResults(out, compiled, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// runJSSpecs renders the tests with generated JavaScript code in a single node process.
+func runJSSpecs(tests []*specTest) ([]specResult, error) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "mustache-spec-js")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	results := make([]specResult, len(tests))
+	var compiled []int
+	jsMain := new(bytes.Buffer)
+	jsMain.WriteString("async function run(path, data) {\n" +
+		"\ttry {\n\t\treturn {output: (await import(path)).default(data)}\n" +
+		"\t} catch (e) {\n\t\treturn {output: '', error: String(e)}\n\t}\n}\n" +
+		"const results = []\n")
+	for i, test := range tests {
+		var data any
+		if err := json.Unmarshal(test.Data, &data); err != nil {
+			results[i].err = err
+			continue
+		}
+		js, err := compileJS("template", test.Template, specPartials(test), &jsOptions{})
+		if err != nil {
+			results[i].err = err
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("t%d.mjs", i)), js, 0o666); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(jsMain, "results.push(await run('./t%d.mjs', %s))\n", i, jsSpecData(data))
+		compiled = append(compiled, i)
+	}
+	jsMain.WriteString("process.stdout.write(JSON.stringify(results))\n")
+	if err := os.WriteFile(filepath.Join(dir, "main.mjs"), jsMain.Bytes(), 0o666); err != nil {
+		return nil, err
+	}
+
+	c := exec.Command(nodePath, "main.mjs")
+	c.Dir = dir
+	c.Stderr = os.Stderr
+	out, err := c.Output()
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeSpecResults(out, compiled, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// decodeSpecResults decodes the JSON results written by a test program
+// into the results of the tests at the given indices.
+func decodeSpecResults(out []byte, indices []int, results []specResult) error {
+	var decoded []struct {
+		Output string
+		Error  string
+	}
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		return err
+	}
+	if len(decoded) != len(indices) {
+		return fmt.Errorf("got %d results; want %d", len(decoded), len(indices))
+	}
+	for j, i := range indices {
+		results[i].output = decoded[j].Output
+		if decoded[j].Error != "" {
+			results[i].err = errors.New(decoded[j].Error)
+		}
+	}
+	return nil
+}
+
+func specPartials(test *specTest) func(name string) (string, error) {
+	return func(name string) (string, error) {
+		return test.Partials[name], nil
+	}
+}
+
+// specCode returns the source code of a lambda in the given language
+// if v is a spec object that represents code, like {"__tag__": "code", "go": "..."}.
+func specCode(v map[string]any, lang string) (string, bool) {
+	if v["__tag__"] != "code" {
+		return "", false
+	}
+	code, ok := v[lang].(string)
+	return code, ok
+}
+
+// goSpecData returns a Go expression for decoded JSON data
+// with the Go code of lambdas in place of their spec objects.
+func goSpecData(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return "float64(" + strconv.FormatFloat(v, 'g', -1, 64) + ")"
+	case string:
+		return strconv.Quote(v)
+	case []any:
+		elems := make([]string, len(v))
+		for i, elem := range v {
+			elems[i] = goSpecData(elem)
+		}
+		return "[]any{" + strings.Join(elems, ", ") + "}"
+	case map[string]any:
+		if code, ok := specCode(v, "go"); ok {
+			return code
+		}
+		var fields []string
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			fields = append(fields, strconv.Quote(k)+": "+goSpecData(v[k]))
+		}
+		return "map[string]any{" + strings.Join(fields, ", ") + "}"
+	default:
+		panic(fmt.Sprintf("unexpected JSON value %T", v))
+	}
+}
+
+// jsSpecData returns a JavaScript expression for decoded JSON data
+// with the JavaScript code of lambdas in place of their spec objects.
+func jsSpecData(v any) string {
+	switch v := v.(type) {
+	case []any:
+		elems := make([]string, len(v))
+		for i, elem := range v {
+			elems[i] = jsSpecData(elem)
+		}
+		return "[" + strings.Join(elems, ",") + "]"
+	case map[string]any:
+		if code, ok := specCode(v, "js"); ok {
+			return "(" + code + ")"
+		}
+		var fields []string
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			fields = append(fields, jsSpecData(k)+":"+jsSpecData(v[k]))
+		}
+		return "{" + strings.Join(fields, ",") + "}"
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			panic(err)
+		}
+		return string(data)
+	}
+}
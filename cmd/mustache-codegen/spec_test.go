@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestWriteSpecMatrix(t *testing.T) {
+	suites := []*specSuite{
+		{name: "basic", tests: []*specTest{
+			{Name: "One", Expected: "1"},
+			{Name: "Two", Expected: "2"},
+		}},
+		{name: "~extra", tests: []*specTest{
+			{Name: "Three", Expected: "3"},
+		}},
+	}
+	backends := []specBackend{
+		{name: "good", run: func(tests []*specTest) ([]specResult, error) {
+			return []specResult{{output: "1"}, {output: "2"}, {output: "3"}}, nil
+		}},
+		{name: "bad", run: func(tests []*specTest) ([]specResult, error) {
+			return []specResult{{output: "1"}, {output: "two"}, {err: errors.New("oops")}}, nil
+		}},
+	}
+	buf := new(bytes.Buffer)
+	if err := writeSpecMatrix(buf, suites, backends, true); err != nil {
+		t.Fatal(err)
+	}
+	const want = "SUITE   TEST   good  bad\n" +
+		"basic   One    pass  pass\n" +
+		"basic   Two    pass  FAIL\n" +
+		"~extra  Three  pass  FAIL\n" +
+		"\n" +
+		"basic   TOTAL  2/2  1/2\n" +
+		"~extra  TOTAL  1/1  0/1\n" +
+		"\n" +
+		"basic/Two (bad): output \"two\"; want \"2\"\n" +
+		"~extra/Three (bad): oops\n"
+	if got := buf.String(); got != want {
+		t.Errorf("matrix:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSpecData(t *testing.T) {
+	var data any
+	const input = `{"b": [1.5, null, true], "a": "x\"", "f": {"__tag__": "code", "go": "func() string { return \"y\" }", "js": "function() { return 'y' }"}}`
+	if err := json.Unmarshal([]byte(input), &data); err != nil {
+		t.Fatal(err)
+	}
+	const wantGo = `map[string]any{"a": "x\"", "b": []any{float64(1.5), nil, true}, "f": func() string { return "y" }}`
+	if got := goSpecData(data); got != wantGo {
+		t.Errorf("goSpecData(...) = %s; want %s", got, wantGo)
+	}
+	const wantJS = `{"a":"x\"","b":[1.5,null,true],"f":(function() { return 'y' })}`
+	if got := jsSpecData(data); got != wantJS {
+		t.Errorf("jsSpecData(...) = %s; want %s", got, wantJS)
+	}
+}
+
+// TestRunSpecs verifies that every backend passes the required suites
+// of the specification when run by the spec command.
+func TestRunSpecs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping for -short")
+	}
+	suites, err := loadSpecSuites()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suites) != 9 {
+		t.Errorf("loaded %d suites; want 9", len(suites))
+	}
+	var tests []*specTest
+	for _, suite := range suites {
+		tests = append(tests, suite.tests...)
+	}
+	backends := map[string]func() ([]specResult, error){
+		"go": func() ([]specResult, error) { return runGoSpecs(tests, "../..") },
+		"js": func() ([]specResult, error) { return runJSSpecs(tests) },
+	}
+	for name, run := range backends {
+		t.Run(name, func(t *testing.T) {
+			results, err := run()
+			if errors.Is(err, exec.ErrNotFound) {
+				t.Skip(err)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			i := 0
+			for _, suite := range suites {
+				for _, test := range suite.tests {
+					r := results[i]
+					i++
+					if strings.HasPrefix(suite.name, "~") && suite.name != "~inheritance" {
+						continue
+					}
+					if r.err != nil || r.output != test.Expected {
+						t.Errorf("%s/%s: output %q, error %v; want %q", suite.name, test.Name, r.output, r.err, test.Expected)
+					}
+				}
+			}
+		})
+	}
+}
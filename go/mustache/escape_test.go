@@ -0,0 +1,42 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package mustache
+
+import "testing"
+
+func TestEscaper(t *testing.T) {
+	tests := []struct {
+		e    Escaper
+		s    string
+		want string
+	}{
+		{EscapeMinimal, "", ""},
+		{EscapeMinimal, "plain", "plain"},
+		{EscapeMinimal, `<a href="x">&</a>`, "&lt;a href=&quot;x&quot;&gt;&amp;&lt;/a&gt;"},
+		{EscapeMinimal, "it's", "it's"},
+		{EscapeQuotes, "it's", "it&#39;s"},
+		{EscapeQuotes, `'"'`, "&#39;&quot;&#39;"},
+		{EscapeQuotes, "café & crème", "café &amp; crème"},
+		{EscapeNone, `<a href='x'>&</a>`, `<a href='x'>&</a>`},
+		// The zero value escapes quotes.
+		{0, "it's", "it&#39;s"},
+	}
+	for _, test := range tests {
+		if got := test.e.EscapeString(test.s); got != test.want {
+			t.Errorf("Escaper(%d).EscapeString(%q) = %q; want %q", test.e, test.s, got, test.want)
+		}
+	}
+}
+
+func TestParseEscaper(t *testing.T) {
+	for _, e := range []Escaper{EscapeMinimal, EscapeQuotes, EscapeNone} {
+		name := map[Escaper]string{EscapeMinimal: "minimal", EscapeQuotes: "quotes", EscapeNone: "none"}[e]
+		if got, ok := ParseEscaper(name); !ok || got != e {
+			t.Errorf("ParseEscaper(%q) = %d, %t; want %d, true", name, got, ok, e)
+		}
+	}
+	if _, ok := ParseEscaper("html"); ok {
+		t.Error(`ParseEscaper("html") reported true`)
+	}
+}
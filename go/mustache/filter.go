@@ -0,0 +1,23 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package mustache
+
+import "reflect"
+
+// FilterArg returns the value that a variable passes to the first filter
+// in a pipeline like {{price | currency}}:
+// the value that v holds, or nil if v is invalid
+// or holds a value obtained from an unexported field.
+func FilterArg(v reflect.Value) any {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// FilterResult returns the [reflect.Value] of the result of the last filter
+// in a pipeline, which is rendered in the same way as a variable's value.
+func FilterResult(x any) reflect.Value {
+	return reflect.ValueOf(x)
+}
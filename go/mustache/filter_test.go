@@ -0,0 +1,34 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package mustache
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterArg(t *testing.T) {
+	type private struct{ n int }
+	tests := []struct {
+		v    reflect.Value
+		want any
+	}{
+		{reflect.Value{}, nil},
+		{reflect.ValueOf(42), 42},
+		{reflect.ValueOf("x"), "x"},
+		{reflect.ValueOf(private{n: 1}).Field(0), nil},
+	}
+	for _, test := range tests {
+		if got := FilterArg(test.v); got != test.want {
+			t.Errorf("FilterArg(%v) = %#v; want %#v", test.v, got, test.want)
+		}
+	}
+
+	if got := ToString(FilterResult(nil)); got != "" {
+		t.Errorf("ToString(FilterResult(nil)) = %q; want \"\"", got)
+	}
+	if got := ToString(FilterResult(1.5)); got != "1.5" {
+		t.Errorf("ToString(FilterResult(1.5)) = %q; want \"1.5\"", got)
+	}
+}
@@ -0,0 +1,485 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+// Package interp executes Mustache templates without generating code.
+// Templates are rendered with the same semantics as the functions generated by mustache-codegen
+// by using the runtime support in the [mustache] package.
+//
+// The interpreter is intended for development:
+// mustache-codegen's -go-interpret option generates a function
+// with the same signature as the compiled template function
+// that renders the template file with [RenderFile],
+// so that edits to the template take effect without regenerating code.
+// Use -go-build-tags to select between the two functions with a build tag.
+package interp
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kagisearch/mustache-codegen/go/mustache"
+	"github.com/kagisearch/mustache-codegen/internal/syntax"
+)
+
+// Template is a parsed Mustache template along with its partials.
+type Template struct {
+	tags     []syntax.Tag
+	partials map[string][]syntax.Tag
+	// escaper is the escaper set by the template's ESCAPE pragma, if any.
+	escaper    mustache.Escaper
+	hasEscaper bool
+	// strict is whether the template declares the STRICT pragma.
+	strict bool
+	// source and partialSources are the text of the template and its partials,
+	// from which [Template.frontMatter] parses them again.
+	source         string
+	partialSources map[string]string
+
+	// withFrontMatter is the template parsed with front matter,
+	// created on first use by [Template.frontMatter].
+	frontMatterOnce sync.Once
+	withFrontMatter *Template
+	frontMatterErr  error
+	// minified is the template with its HTML minified,
+	// created on first use by [Template.minifyHTML].
+	minifyOnce sync.Once
+	minified   *Template
+	// translatable is the template with its {{#_i18n}} sections parsed as messages,
+	// created on first use by [Template.i18n].
+	i18nOnce     sync.Once
+	translatable *Template
+	i18nErr      error
+}
+
+// Parse parses a Mustache template.
+// load is called to obtain the source of each partial or parent the template refers to.
+// If load returns an empty string, the partial is treated as empty.
+//
+// The template's ESCAPE and STRICT pragmas apply when it is rendered,
+// as they do to the generated code.
+// Pragmas declared by partials have no effect,
+// but like unknown pragmas, malformed ones are errors.
+// Like in functions generated without -go-filters,
+// "|" is an ordinary character in names.
+// A block between two "---" lines at the start of the template is text
+// unless the template is rendered with [RenderOptions.FrontMatter].
+func Parse(source string, load func(name string) (string, error)) (*Template, error) {
+	return parseTemplate(source, load, &syntax.Options{})
+}
+
+// parseTemplate is like [Parse], but parses the template and its partials with opts.
+// The interpreter cannot call the filter functions of generated code,
+// so opts never enables filters.
+func parseTemplate(source string, load func(name string) (string, error), opts *syntax.Options) (*Template, error) {
+	tags, err := syntax.ParseOptions(source, opts)
+	if err != nil {
+		return nil, err
+	}
+	pragmas, err := syntax.Pragmas(tags)
+	if err != nil {
+		return nil, err
+	}
+	t := &Template{
+		tags:           tags,
+		partials:       make(map[string][]syntax.Tag),
+		source:         source,
+		partialSources: make(map[string]string),
+	}
+	if name, ok := pragmas["ESCAPE"]; ok {
+		t.escaper, t.hasEscaper = mustache.ParseEscaper(name)
+		if !t.hasEscaper {
+			return nil, fmt.Errorf("unknown escaper %q in pragma ESCAPE", name)
+		}
+	}
+	_, t.strict = pragmas["STRICT"]
+
+	var gatherPartials func(tags []syntax.Tag) error
+	gatherPartials = func(tags []syntax.Tag) error {
+		for tag := range syntax.WalkTags(tags) {
+			if tag.Type != syntax.Partial && tag.Type != syntax.Parent {
+				continue
+			}
+			if _, loaded := t.partials[tag.S]; loaded {
+				continue
+			}
+			source, err := load(tag.S)
+			if err != nil {
+				return err
+			}
+			t.partialSources[tag.S] = source
+			partialTags, err := syntax.ParseOptions(source, opts)
+			if err == nil {
+				_, err = syntax.Pragmas(partialTags)
+			}
+			if err != nil {
+				return fmt.Errorf("partial %s: %v", tag.S, err)
+			}
+			t.partials[tag.S] = partialTags
+			if err := gatherPartials(partialTags); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := gatherPartials(tags); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// ParseFile parses the Mustache template in the named file.
+// Partials are loaded from files with the ".mustache" extension
+// in the same directory as the template, like mustache-codegen does.
+func ParseFile(path string) (*Template, error) {
+	t, _, err := parseFile(path)
+	return t, err
+}
+
+// parseFile parses the Mustache template in the named file
+// and returns the modification times of the files it read,
+// using the zero time for partial files that do not exist.
+func parseFile(path string) (*Template, map[string]time.Time, error) {
+	files := make(map[string]time.Time)
+	source, modTime, err := readFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	files[path] = modTime
+	dir := filepath.Dir(path)
+	t, err := Parse(source, func(name string) (string, error) {
+		partialPath := filepath.Join(dir, name+".mustache")
+		source, modTime, err := readFile(partialPath)
+		if os.IsNotExist(err) {
+			files[partialPath] = time.Time{}
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		files[partialPath] = modTime
+		return source, nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return t, files, nil
+}
+
+// readFile returns the content of the named file
+// along with its modification time.
+func readFile(path string) (string, time.Time, error) {
+	// Stat before reading so that a change during the read
+	// is observed by a later stat.
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return string(data), info.ModTime(), nil
+}
+
+// Render renders the template with the given data into buf,
+// escaping variables with [mustache.EscapeQuotes].
+func (t *Template) Render(buf *bytes.Buffer, data any) {
+	t.RenderOptions(buf, data, &RenderOptions{})
+}
+
+// RenderOptions is the set of options for [Template.RenderOptions] and [RenderFileOptions].
+// The zero value renders templates like [Template.Render].
+type RenderOptions struct {
+	// Escaper is the set of characters escaped in variables
+	// unless the template declares an ESCAPE pragma.
+	Escaper mustache.Escaper
+	// Strict is whether variables that cannot be found
+	// panic with a [*mustache.MissingError] like they do in functions generated with -strict.
+	// Templates that declare the STRICT pragma are always strict.
+	Strict bool
+	// I18n is whether {{#_i18n}} sections are translatable messages
+	// like they are in functions generated with -go-translator.
+	// Otherwise they are ordinary sections.
+	I18n bool
+	// Translator translates the messages in {{#_i18n}} sections if I18n is set.
+	// If it is nil, the messages are rendered as written.
+	Translator mustache.Translator
+	// MinifyHTML is whether to minify the HTML in the text of the template and its partials
+	// like mustache-codegen's -minify-html option.
+	MinifyHTML bool
+	// FrontMatter is whether a block between two "---" lines at the start of the template
+	// is front matter, which is not rendered, like mustache-codegen's -front-matter option.
+	// Otherwise it is text.
+	FrontMatter bool
+}
+
+// RenderOptions renders the template with the given data into buf
+// as specified by opts.
+// If opts.FrontMatter is set, it panics if the template's front matter is invalid.
+// If opts.I18n is set, it panics if a {{#_i18n}} section
+// contains tags other than variables.
+func (t *Template) RenderOptions(buf *bytes.Buffer, data any, opts *RenderOptions) {
+	if opts.FrontMatter {
+		var err error
+		if t, err = t.frontMatter(); err != nil {
+			panic(err)
+		}
+	}
+	if opts.I18n {
+		var err error
+		if t, err = t.i18n(); err != nil {
+			panic(err)
+		}
+	}
+	if opts.MinifyHTML {
+		t = t.minifyHTML()
+	}
+	stack := mustache.GetStack(data)
+	defer mustache.PutStack(stack)
+	stack.SetTranslator(opts.Translator)
+	r := &renderer{t: t, e: opts.Escaper, strict: opts.Strict || t.strict}
+	if t.hasEscaper {
+		r.e = t.escaper
+	}
+	r.renderTags(buf, t.tags, stack, mustache.Blocks{}, "")
+}
+
+// frontMatter returns the template parsed again with its front matter
+// recognized by [syntax.ParseOptions].
+func (t *Template) frontMatter() (*Template, error) {
+	t.frontMatterOnce.Do(func() {
+		t.withFrontMatter, t.frontMatterErr = parseTemplate(t.source, func(name string) (string, error) {
+			return t.partialSources[name], nil
+		}, &syntax.Options{FrontMatter: true})
+	})
+	return t.withFrontMatter, t.frontMatterErr
+}
+
+// i18n returns the template with the {{#_i18n}} sections in it and its partials
+// replaced by [syntax.Translation] tags.
+func (t *Template) i18n() (*Template, error) {
+	t.i18nOnce.Do(func() {
+		tr := &Template{
+			partials:   make(map[string][]syntax.Tag, len(t.partials)),
+			escaper:    t.escaper,
+			hasEscaper: t.hasEscaper,
+			strict:     t.strict,
+		}
+		tr.tags, t.i18nErr = syntax.TranslationTags(t.tags)
+		if t.i18nErr != nil {
+			return
+		}
+		for name, tags := range t.partials {
+			tr.partials[name], t.i18nErr = syntax.TranslationTags(tags)
+			if t.i18nErr != nil {
+				t.i18nErr = fmt.Errorf("partial %s: %v", name, t.i18nErr)
+				return
+			}
+		}
+		t.translatable = tr
+	})
+	return t.translatable, t.i18nErr
+}
+
+// minifyHTML returns the template with the HTML in its text
+// and the text of its partials minified by [syntax.MinifyHTML]
+// and [syntax.MinifyHTMLPartial].
+func (t *Template) minifyHTML() *Template {
+	t.minifyOnce.Do(func() {
+		t.minified = &Template{
+			tags:       syntax.MinifyHTML(t.tags),
+			partials:   make(map[string][]syntax.Tag, len(t.partials)),
+			escaper:    t.escaper,
+			hasEscaper: t.hasEscaper,
+			strict:     t.strict,
+		}
+		for name, tags := range t.partials {
+			t.minified.partials[name] = syntax.MinifyHTMLPartial(tags)
+		}
+	})
+	return t.minified
+}
+
+// renderer is the state of a call to [Template.RenderOptions].
+type renderer struct {
+	t *Template
+	e mustache.Escaper
+	// strict is whether variables that cannot be found panic.
+	strict bool
+}
+
+// renderTags renders a list of tags.
+// It mirrors the code generated by mustache-codegen's Go backend:
+// blocks is the set of block arguments in scope
+// and indent is written at every indent point.
+func (r *renderer) renderTags(buf *bytes.Buffer, tags []syntax.Tag, stack *mustache.Stack, blocks mustache.Blocks, indent string) {
+	for _, tag := range tags {
+		switch tag.Type {
+		case syntax.Literal:
+			buf.WriteString(tag.S)
+		case syntax.IndentPoint:
+			buf.WriteString(indent)
+		case syntax.Variable:
+			r.e.Escape(buf, mustache.ToString(r.value(stack, tag.S)))
+		case syntax.RawVariable:
+			buf.WriteString(mustache.ToString(r.value(stack, tag.S)))
+		case syntax.Translation:
+			stack.Translate(buf, r.e, tag.S)
+		case syntax.Pragma, syntax.FrontMatter:
+			// Pragmas and front matter do not render anything.
+		case syntax.Section:
+			for it := stack.Iterate(lookup(stack, tag.S)); it.Next(); {
+				stack.Push(it.Value())
+				r.renderTags(buf, tag.Body, stack, blocks, indent)
+				stack.Pop()
+			}
+		case syntax.InvertedSection:
+			if mustache.IsFalsyOrEmptyList(lookup(stack, tag.S)) {
+				r.renderTags(buf, tag.Body, stack, blocks, indent)
+			}
+		case syntax.Partial:
+			r.renderTags(buf, r.t.partials[tag.S], stack, mustache.Blocks{}, indent+tag.Indent)
+		case syntax.Block:
+			if b, env := stack.Block(blocks, tag.S); b != nil {
+				argIndent := tag.Indent
+				if tag.IndentArgument {
+					argIndent = indent + tag.Indent
+				}
+				b(buf, argIndent, stack, env)
+			} else {
+				r.renderTags(buf, tag.Body, stack, blocks, indent)
+			}
+		case syntax.Parent:
+			table := r.blockTable(tag)
+			r.renderTags(buf, r.t.partials[tag.S], stack, stack.PushBlocks(blocks, table), indent+tag.Indent)
+			stack.PopBlocks()
+		default:
+			panic(fmt.Sprintf("unhandled tag %d", tag.Type))
+		}
+	}
+}
+
+// blockTable returns a [mustache.BlockTable] for the block arguments of a parent tag.
+func (r *renderer) blockTable(parent syntax.Tag) mustache.BlockTable {
+	return func(name string) mustache.BlockFunc {
+		// Later block arguments with the same name take precedence.
+		for i := len(parent.Body) - 1; i >= 0; i-- {
+			arg := parent.Body[i]
+			if arg.Type == syntax.Block && arg.S == name {
+				return func(buf *bytes.Buffer, indent string, stack *mustache.Stack, blocks mustache.Blocks) {
+					r.renderTags(buf, arg.Body, stack, blocks, indent)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// value returns the value of the variable with the given name,
+// checking that it can be found if the template is rendered strictly.
+func (r *renderer) value(stack *mustache.Stack, name string) reflect.Value {
+	v := lookup(stack, name)
+	if r.strict && name != "." {
+		v = mustache.Strict(v, name)
+	}
+	return v
+}
+
+func lookup(stack *mustache.Stack, name string) reflect.Value {
+	if name == "." {
+		return stack.Top()
+	}
+	return stack.Lookup(strings.Split(name, ".")...)
+}
+
+// A Cache is a set of templates parsed from files
+// that are parsed again when the files change.
+// The zero value is an empty cache.
+// A Cache is safe to use from multiple goroutines.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	template *Template
+	// files maps the paths of the files read while parsing the template
+	// to their modification times.
+	files map[string]time.Time
+}
+
+// Get returns the parsed template for the named file,
+// parsing it if the file or any of its partials have changed
+// since the last call to Get.
+func (c *Cache) Get(path string) (*Template, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e := c.entries[path]; e != nil && !e.stale() {
+		return e.template, nil
+	}
+	t, files, err := parseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if c.entries == nil {
+		c.entries = make(map[string]*cacheEntry)
+	}
+	c.entries[path] = &cacheEntry{template: t, files: files}
+	return t, nil
+}
+
+// stale reports whether any of the files the template was parsed from
+// have changed.
+func (e *cacheEntry) stale() bool {
+	for path, modTime := range e.files {
+		info, err := os.Stat(path)
+		switch {
+		case os.IsNotExist(err):
+			if !modTime.IsZero() {
+				return true
+			}
+		case err != nil:
+			return true
+		case !info.ModTime().Equal(modTime):
+			return true
+		}
+	}
+	return false
+}
+
+var defaultCache Cache
+
+// RenderFile renders the template in the named file with the given data into buf,
+// escaping variables with [mustache.EscapeQuotes].
+// Templates are cached and reparsed when their files change.
+func RenderFile(buf *bytes.Buffer, path string, data any) error {
+	return RenderFileOptions(buf, path, data, &RenderOptions{})
+}
+
+// RenderFileOptions is like [RenderFile], but renders the template as specified by opts.
+func RenderFileOptions(buf *bytes.Buffer, path string, data any, opts *RenderOptions) error {
+	t, err := defaultCache.Get(path)
+	if err != nil {
+		return err
+	}
+	// Report errors that RenderOptions would panic with.
+	checked := t
+	if opts.FrontMatter {
+		if checked, err = t.frontMatter(); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	if opts.I18n {
+		if _, err := checked.i18n(); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	t.RenderOptions(buf, data, opts)
+	return nil
+}
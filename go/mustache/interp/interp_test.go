@@ -0,0 +1,277 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package interp
+
+import (
+	"bytes"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kagisearch/mustache-codegen/go/mustache"
+)
+
+func TestParse(t *testing.T) {
+	tmpl, err := Parse("{{<layout}}{{$body}}Hi, {{name}}!{{/body}}{{/layout}}", func(name string) (string, error) {
+		return map[string]string{
+			"layout": "<p>{{$body}}default{{/body}}</p>{{>footer}}",
+			"footer": "{{#items}}[{{.}}]{{/items}}",
+		}[name], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	tmpl.Render(buf, map[string]any{"name": "<World>", "items": []int{1, 2}})
+	const want = "<p>Hi, &lt;World&gt;!</p>[1][2]"
+	if got := buf.String(); got != want {
+		t.Errorf("Render(...) = %q; want %q", got, want)
+	}
+
+	// Without filters, "|" is part of names, as in generated code.
+	tmpl, err = Parse("{{a|b}} {{>part}}", func(name string) (string, error) { return "{{&c|d}}", nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	tmpl.Render(buf, map[string]any{"a|b": "x", "c|d": "<y>"})
+	if got, want := buf.String(), "x <y>"; got != want {
+		t.Errorf("Render(...) = %q; want %q", got, want)
+	}
+}
+
+func TestRenderEmptySeq(t *testing.T) {
+	tmpl, err := Parse("[{{#items}}x{{/items}}{{^items}}none{{/items}}]", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		items iter.Seq[int]
+		want  string
+	}{
+		{slices.Values([]int{}), "[none]"},
+		{slices.Values([]int{1, 2}), "[xx]"},
+	}
+	for _, test := range tests {
+		buf := new(bytes.Buffer)
+		tmpl.Render(buf, map[string]any{"items": test.items})
+		if got := buf.String(); got != test.want {
+			t.Errorf("Render(...) = %q; want %q", got, test.want)
+		}
+	}
+}
+
+func TestRenderMinifyHTML(t *testing.T) {
+	partials := map[string]string{
+		"footer": "<p>\n  bye\n</p>",
+		"code":   "a\nb\n",
+	}
+	tmpl, err := Parse("<ul>\n  {{#items}}<li> {{.}} </li>\n  {{/items}}\n</ul>\n<pre>\n  {{>code}}\n</pre>\n{{>footer}}", func(name string) (string, error) {
+		return partials[name], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]any{"items": []int{1, 2}}
+	tests := []struct {
+		minify bool
+		want   string
+	}{
+		{false, "<ul>\n  <li> 1 </li>\n<li> 2 </li>\n</ul>\n<pre>\n  a\n  b\n</pre>\n<p>\n  bye\n</p>"},
+		{true, "<ul>\n<li> 1 </li>\n<li> 2 </li>\n</ul>\n<pre>\n  a\n  b\n</pre>\n<p>\nbye\n</p>"},
+	}
+	for _, test := range tests {
+		buf := new(bytes.Buffer)
+		tmpl.RenderOptions(buf, data, &RenderOptions{MinifyHTML: test.minify})
+		if got := buf.String(); got != test.want {
+			t.Errorf("RenderOptions(..., {MinifyHTML: %t}) = %q; want %q", test.minify, got, test.want)
+		}
+	}
+}
+
+func TestRenderFrontMatter(t *testing.T) {
+	partials := map[string]string{"footer": "---\nx: 1\n---\nbye"}
+	tmpl, err := Parse("---\ntitle: Home\n---\n{{title}} {{>footer}}", func(name string) (string, error) {
+		return partials[name], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		frontMatter bool
+		want        string
+	}{
+		{false, "---\ntitle: Home\n---\n ---\nx: 1\n---\nbye"},
+		{true, " bye"},
+	}
+	for _, test := range tests {
+		buf := new(bytes.Buffer)
+		tmpl.RenderOptions(buf, nil, &RenderOptions{FrontMatter: test.frontMatter})
+		if got := buf.String(); got != test.want {
+			t.Errorf("RenderOptions(..., {FrontMatter: %t}) = %q; want %q", test.frontMatter, got, test.want)
+		}
+	}
+
+	// Blocks that are not valid front matter are text unless front matter is enabled.
+	tmpl, err = Parse("---\n- a\n---\n- b\n", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	tmpl.Render(buf, nil)
+	if got, want := buf.String(), "---\n- a\n---\n- b\n"; got != want {
+		t.Errorf("Render(...) = %q; want %q", got, want)
+	}
+	if _, err := tmpl.frontMatter(); err == nil {
+		t.Error("frontMatter did not return an error for invalid front matter")
+	}
+}
+
+func TestRenderPragmas(t *testing.T) {
+	tmpl, err := Parse("{{%ESCAPE=quotes}}\n{{%STRICT}}\n{{s}}", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	tmpl.RenderOptions(buf, map[string]any{"s": "it's"}, &RenderOptions{Escaper: mustache.EscapeNone})
+	if got, want := buf.String(), "it&#39;s"; got != want {
+		t.Errorf("RenderOptions(...) = %q; want %q", got, want)
+	}
+	if err := renderMissing(tmpl, &RenderOptions{}); err == nil {
+		t.Error("template with STRICT pragma rendered a missing variable")
+	}
+
+	tmpl, err = Parse("{{s}}{{.}}", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := renderMissing(tmpl, &RenderOptions{}); err != nil {
+		t.Errorf("template without STRICT pragma: %v", err)
+	}
+	if err := renderMissing(tmpl, &RenderOptions{Strict: true}); err == nil {
+		t.Error("RenderOptions with Strict rendered a missing variable")
+	}
+
+	badSources := []string{
+		"{{%BOGUS}}",
+		"{{%ESCAPE=html}}",
+		"{{>part}}",
+	}
+	for _, source := range badSources {
+		_, err := Parse(source, func(name string) (string, error) { return "{{%BOGUS}}", nil })
+		if err == nil {
+			t.Errorf("Parse(%q) did not return an error", source)
+		}
+	}
+}
+
+// renderMissing renders tmpl with no data,
+// returning the [*mustache.MissingError] that rendering panics with, if any.
+func renderMissing(tmpl *Template, opts *RenderOptions) (err *mustache.MissingError) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = v.(*mustache.MissingError)
+		}
+	}()
+	tmpl.RenderOptions(new(bytes.Buffer), map[string]any{}, opts)
+	return nil
+}
+
+// upperTranslator translates messages to upper case.
+type upperTranslator struct{}
+
+func (upperTranslator) Translate(message string) string {
+	return strings.ToUpper(message[:1]) + message[1:]
+}
+
+func TestRenderTranslated(t *testing.T) {
+	tmpl, err := Parse("{{=<% %>=}}<%#_i18n%>hello, <%name%>!<%/_i18n%> <%#_i18n%>bye<%/_i18n%>", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := map[string]any{"name": "<World>"}
+	tests := []struct {
+		tr   mustache.Translator
+		want string
+	}{
+		{nil, "hello, &lt;World&gt;! bye"},
+		{upperTranslator{}, "Hello, &lt;World&gt;! Bye"},
+	}
+	for _, test := range tests {
+		buf := new(bytes.Buffer)
+		tmpl.RenderOptions(buf, data, &RenderOptions{I18n: true, Translator: test.tr})
+		if got := buf.String(); got != test.want {
+			t.Errorf("RenderOptions(..., {I18n: true, Translator: %v}) = %q; want %q", test.tr, got, test.want)
+		}
+	}
+}
+
+func TestRenderWithoutI18n(t *testing.T) {
+	// Without I18n, _i18n is an ordinary section.
+	tmpl, err := Parse("{{#_i18n}}{{#a}}b{{/a}}{{/_i18n}}", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	tmpl.Render(buf, map[string]any{"_i18n": true, "a": true})
+	if got, want := buf.String(), "b"; got != want {
+		t.Errorf("Render(...) = %q; want %q", got, want)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("RenderOptions(..., {I18n: true}) did not panic for a section that is not a message")
+		}
+	}()
+	tmpl.RenderOptions(new(bytes.Buffer), nil, &RenderOptions{I18n: true})
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "page.mustache")
+	partialPath := filepath.Join(dir, "greeting.mustache")
+	writeFile := func(path, content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o666); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	render := func(c *Cache) string {
+		t.Helper()
+		tmpl, err := c.Get(templatePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := new(bytes.Buffer)
+		tmpl.Render(buf, map[string]string{"name": "World"})
+		return buf.String()
+	}
+
+	t0 := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	writeFile(templatePath, "{{>greeting}}, {{name}}!", t0)
+	writeFile(partialPath, "Hello", t0)
+	c := new(Cache)
+	if got, want := render(c), "Hello, World!"; got != want {
+		t.Errorf("first render = %q; want %q", got, want)
+	}
+
+	writeFile(partialPath, "Goodbye", t0.Add(time.Second))
+	if got, want := render(c), "Goodbye, World!"; got != want {
+		t.Errorf("render after changing partial = %q; want %q", got, want)
+	}
+
+	if err := os.Remove(partialPath); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := render(c), ", World!"; got != want {
+		t.Errorf("render after removing partial = %q; want %q", got, want)
+	}
+}
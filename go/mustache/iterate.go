@@ -0,0 +1,236 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package mustache
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ForEach returns an iterator over the values a section is rendered with.
+// See [Iterate] for details.
+func ForEach(v reflect.Value) iter.Seq[reflect.Value] {
+	return func(yield func(reflect.Value) bool) {
+		it := Iterate(v)
+		defer it.Close()
+		for it.Next() {
+			if !yield(it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Entry is a key-value pair from a map or an [iter.Seq2].
+// Sections push an Entry onto the context stack for each pair they iterate over,
+// so the key and value can be referred to as {{key}} and {{value}}.
+type Entry struct {
+	Key   reflect.Value
+	Value reflect.Value
+}
+
+var entryType = reflect.TypeFor[Entry]()
+
+// entryProperty returns the property of an [Entry] with the given name.
+func entryProperty(v reflect.Value, k string) reflect.Value {
+	var e *Entry
+	if v.CanAddr() {
+		e = v.Addr().Interface().(*Entry)
+	} else {
+		x := v.Interface().(Entry)
+		e = &x
+	}
+	switch k {
+	case "key":
+		return e.Key
+	case "value":
+		return e.Value
+	default:
+		return reflect.Value{}
+	}
+}
+
+// isEntryMap reports whether a section iterates over the entries of a map of type t.
+// Maps with string keys are contexts instead,
+// like objects in other Mustache implementations.
+func isEntryMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() != reflect.String
+}
+
+type iteratorKind uint8
+
+const (
+	iterateNone iteratorKind = iota
+	iterateSingle
+	iterateIndex
+	iterateMap
+	iterateSeq
+	iterateSeq2
+	iterateChan
+)
+
+// Iterator is an iterator over the values a section is rendered with.
+// Unlike [ForEach], using an Iterator does not allocate
+// when iterating over a slice or an array.
+//
+//	for it := Iterate(v); it.Next(); {
+//		e := it.Value()
+//		// ...
+//	}
+type Iterator struct {
+	kind iteratorKind
+	v    reflect.Value
+	curr reflect.Value
+	i, n int
+	// keys is the sorted list of keys of a map.
+	keys []reflect.Value
+	// next and next2 pull values from an iter.Seq or iter.Seq2,
+	// and stop stops pulling.
+	next  func() (reflect.Value, bool)
+	next2 func() (reflect.Value, reflect.Value, bool)
+	stop  func()
+	// stack is the [Stack] that stop is registered with, if any,
+	// at index stopIndex of its stops.
+	stack     *Stack
+	stopIndex int
+}
+
+// Iterate returns an iterator over the values a section is rendered with.
+// Pointers and interfaces will be dereferenced first.
+// If [IsFalsyOrEmptyList] reports true for v, then the iterator yields no values.
+// Otherwise, the iterator yields:
+//
+//   - each element of a slice or an array.
+//   - each value of an [iter.Seq].
+//   - an [Entry] for each key-value pair of an [iter.Seq2].
+//   - an [Entry] for each key-value pair of a map with non-string keys,
+//     in ascending key order.
+//   - each value received from a channel until it is closed.
+//   - v itself for any other value, including maps with string keys.
+//
+// Iterators over functions hold resources until [Iterator.Next] returns false
+// or [Iterator.Close] is called.
+func Iterate(v reflect.Value) Iterator {
+	v = resolve(v)
+	if v.Kind() == reflect.Func && !v.IsNil() {
+		// Pull the values directly instead of checking for a first value
+		// like IsFalsyOrEmptyList, which would call the function twice.
+		switch t := v.Type(); {
+		case t.CanSeq2():
+			next2, stop := iter.Pull2(v.Seq2())
+			return Iterator{kind: iterateSeq2, next2: next2, stop: stop}
+		case t.CanSeq():
+			next, stop := iter.Pull(v.Seq())
+			return Iterator{kind: iterateSeq, next: next, stop: stop}
+		}
+	}
+	if IsFalsyOrEmptyList(v) {
+		return Iterator{}
+	}
+	switch t := v.Type(); t.Kind() {
+	case reflect.Array, reflect.Slice:
+		return Iterator{kind: iterateIndex, v: v, n: v.Len()}
+	case reflect.Map:
+		if isEntryMap(t) {
+			keys := v.MapKeys()
+			slices.SortFunc(keys, compareKeys)
+			return Iterator{kind: iterateMap, v: v, keys: keys, n: len(keys)}
+		}
+	case reflect.Chan:
+		if t.ChanDir()&reflect.RecvDir != 0 {
+			return Iterator{kind: iterateChan, v: v}
+		}
+	}
+	return Iterator{kind: iterateSingle, v: v, n: 1}
+}
+
+// Next advances the iterator to the next value,
+// which will then be available through the [Iterator.Value] method.
+// It returns false when the iteration stops.
+func (it *Iterator) Next() bool {
+	ok := false
+	switch it.kind {
+	case iterateSingle:
+		if ok = it.i < it.n; ok {
+			it.curr = it.v
+			it.i++
+		}
+	case iterateIndex:
+		if ok = it.i < it.n; ok {
+			it.curr = it.v.Index(it.i)
+			it.i++
+		}
+	case iterateMap:
+		if ok = it.i < it.n; ok {
+			k := it.keys[it.i]
+			it.curr = reflect.ValueOf(&Entry{Key: k, Value: it.v.MapIndex(k)})
+			it.i++
+		}
+	case iterateSeq:
+		it.curr, ok = it.next()
+	case iterateSeq2:
+		var k, v reflect.Value
+		if k, v, ok = it.next2(); ok {
+			it.curr = reflect.ValueOf(&Entry{Key: k, Value: v})
+		}
+	case iterateChan:
+		it.curr, ok = it.v.Recv()
+	}
+	if !ok {
+		it.Close()
+	}
+	return ok
+}
+
+// Close releases the resources held by the iterator.
+// The iterator yields no more values after Close is called.
+func (it *Iterator) Close() {
+	if it.stop != nil {
+		it.stop()
+		if it.stack != nil {
+			it.stack.closeStop(it.stopIndex)
+		}
+	}
+	*it = Iterator{}
+}
+
+// Value returns the current value of the iterator.
+func (it *Iterator) Value() reflect.Value {
+	return it.curr
+}
+
+// compareKeys orders map keys:
+// numbers, strings, and booleans by value
+// and other values by their [fmt.Sprint] representation.
+func compareKeys(a, b reflect.Value) int {
+	a, b = resolve(a), resolve(b)
+	if c := cmp.Compare(a.Kind(), b.Kind()); c != 0 {
+		return c
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case b.Bool():
+			return -1
+		default:
+			return 1
+		}
+	default:
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package mustache
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestIterate(t *testing.T) {
+	closedChan := func(values ...int) <-chan int {
+		c := make(chan int, len(values))
+		for _, v := range values {
+			c <- v
+		}
+		close(c)
+		return c
+	}
+
+	tests := []struct {
+		v    any
+		want []any
+	}{
+		{nil, nil},
+		{false, nil},
+		{[]int{}, nil},
+		{[]int{1, 2, 3}, []any{1, 2, 3}},
+		{[2]string{"a", "b"}, []any{"a", "b"}},
+		{"x", []any{"x"}},
+		{map[string]any{}, []any{map[string]any{}}},
+		{map[int]string{}, nil},
+		{map[int]string{3: "c", 1: "a", 2: "b"}, []any{"1=a", "2=b", "3=c"}},
+		{map[any]int{"b": 1, 2: 2, "a": 3, 1: 4}, []any{"1=4", "2=2", "a=3", "b=1"}},
+		{iter.Seq[int](nil), nil},
+		{slices.Values([]int{}), nil},
+		{slices.Values([]int{1, 2}), []any{1, 2}},
+		{maps.All(map[string]int{"a": 1}), []any{"a=1"}},
+		{slices.All([]string{"x", "y"}), []any{"0=x", "1=y"}},
+		{(chan int)(nil), nil},
+		{closedChan(), nil},
+		{closedChan(1, 2), []any{1, 2}},
+		{func() {}, []any{"func"}},
+	}
+	for _, test := range tests {
+		var got []any
+		for it := Iterate(reflect.ValueOf(test.v)); it.Next(); {
+			v := it.Value()
+			switch {
+			case v.Type() == reflect.PointerTo(entryType):
+				e := v.Interface().(*Entry)
+				got = append(got, fmt.Sprintf("%v=%v", e.Key, e.Value))
+			case v.Kind() == reflect.Func:
+				got = append(got, "func")
+			default:
+				got = append(got, v.Interface())
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Iterate(reflect.ValueOf(%#v)) yielded %v; want %v", test.v, got, test.want)
+		}
+	}
+}
+
+func TestEntryLookup(t *testing.T) {
+	s := GetStack(map[string]any{"key": "outer"})
+	defer PutStack(s)
+	for it := Iterate(reflect.ValueOf(map[int]map[string]string{7: {"name": "seven"}})); it.Next(); {
+		s.Push(it.Value())
+		if got := ToString(s.Lookup("key")); got != "7" {
+			t.Errorf(`s.Lookup("key") = %q; want "7"`, got)
+		}
+		if got := ToString(s.Lookup("value", "name")); got != "seven" {
+			t.Errorf(`s.Lookup("value", "name") = %q; want "seven"`, got)
+		}
+		s.Pop()
+	}
+}
+
+func TestStackIterateClose(t *testing.T) {
+	stopped := 0
+	seq := func(yield func(int) bool) {
+		defer func() { stopped++ }()
+		for i := 0; yield(i); i++ {
+		}
+	}
+
+	s := GetStack(nil)
+	for it := s.Iterate(reflect.ValueOf(iter.Seq[int](seq))); it.Next(); {
+		if it.Value().Int() == 2 {
+			it.Close()
+		}
+	}
+	if stopped != 1 {
+		t.Errorf("closed iterator: seq stopped %d times; want 1", stopped)
+	}
+	if len(s.stops) != 0 {
+		t.Errorf("closed iterator: len(s.stops) = %d; want 0", len(s.stops))
+	}
+
+	// A template function that panics in a section leaves its iterators open
+	// until it returns its stack.
+	it := s.Iterate(reflect.ValueOf(iter.Seq[int](seq)))
+	it.Next()
+	PutStack(s)
+	if stopped != 2 {
+		t.Errorf("PutStack: seq stopped %d times; want 2", stopped)
+	}
+}
@@ -2,13 +2,22 @@
 // SPDX-License-Identifier: MIT
 
 // Package mustache provides runtime support for template functions generated by mustache-codegen.
+//
+// Names in tags are looked up as struct fields or map keys (see [Lookup]).
+// Sections are rendered once for each value yielded by [Iterate]:
+// slices, arrays, [iter.Seq] and [iter.Seq2] functions, channels,
+// and maps with non-string keys are iterated over,
+// and key-value pairs are available as {{key}} and {{value}}.
+// Any other value that is not falsy (see [IsFalsyOrEmptyList])
+// renders the section once with the value as the context,
+// including maps with string keys.
 package mustache
 
 import (
 	"fmt"
-	"iter"
 	"math"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -20,8 +29,12 @@ func Lookup(contextStack []reflect.Value, path string) reflect.Value {
 	if path == "." {
 		return contextStack[len(contextStack)-1]
 	}
-	parts := strings.Split(path, ".")
+	return lookupParts(contextStack, strings.Split(path, "."))
+}
 
+// lookupParts finds the last value for the given dot-separated key parts
+// in the contextStack.
+func lookupParts(contextStack []reflect.Value, parts []string) reflect.Value {
 	// Look through context stack for first part.
 	var v reflect.Value
 	for i := len(contextStack) - 1; i >= 0; i-- {
@@ -37,12 +50,31 @@ func Lookup(contextStack []reflect.Value, path string) reflect.Value {
 	return v
 }
 
+var jsonObjectType = reflect.TypeFor[map[string]any]()
+
 func property(v reflect.Value, k string) reflect.Value {
 	v = resolve(v)
 	switch v.Kind() {
 	case reflect.Struct:
+		if v.Type() == entryType {
+			return entryProperty(v, k)
+		}
 		return v.FieldByName(k)
 	case reflect.Map:
+		// Fast path for maps decoded from JSON
+		// that avoids allocating a key value.
+		if v.Type() == jsonObjectType && v.CanInterface() {
+			x, ok := v.Interface().(map[string]any)[k]
+			switch {
+			case !ok:
+				return reflect.Value{}
+			case x == nil:
+				// Distinguish between a missing key and a nil value.
+				return reflect.Zero(jsonObjectType.Elem())
+			default:
+				return reflect.ValueOf(x)
+			}
+		}
 		ktype := v.Type().Key()
 		if ktype.Kind() != reflect.String {
 			return reflect.Value{}
@@ -66,10 +98,54 @@ func ToString(v reflect.Value) string {
 	if k := v.Kind(); (k == reflect.Pointer || k == reflect.Interface) && v.IsNil() {
 		return ""
 	}
+	// Avoid fmt for common types that don't have methods
+	// (which could include a String method).
+	if v.Type().NumMethod() == 0 {
+		switch v.Kind() {
+		case reflect.String:
+			return v.String()
+		case reflect.Bool:
+			return strconv.FormatBool(v.Bool())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(v.Int(), 10)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return strconv.FormatUint(v.Uint(), 10)
+		case reflect.Float32:
+			return strconv.FormatFloat(v.Float(), 'g', -1, 32)
+		case reflect.Float64:
+			return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+		}
+	}
 	return fmt.Sprint(v)
 }
 
+// MissingError is the value that [Strict] panics with
+// when a variable's name cannot be found.
+type MissingError struct {
+	// Name is the variable's name.
+	Name string
+}
+
+func (e *MissingError) Error() string {
+	return fmt.Sprintf("mustache: missing value for {{%s}}", e.Name)
+}
+
+// Strict returns v if it is valid.
+// Otherwise, it panics with a [*MissingError] for the variable with the given name.
+// Templates that declare {{%STRICT}} check the values of their variables with Strict.
+func Strict(v reflect.Value, name string) reflect.Value {
+	if !v.IsValid() {
+		panic(&MissingError{Name: name})
+	}
+	return v
+}
+
 // IsFalsyOrEmptyList reports whether v is invalid, nil, false, an empty string, or an empty list.
+// Maps that a section iterates over (see [Iterate]) are empty lists if they have no entries.
+// [iter.Seq] and [iter.Seq2] functions are empty lists if they yield no values,
+// which is checked by calling them and stopping after the first value.
+// Channels and other functions are only falsy if they are nil,
+// since a channel cannot be checked for values without receiving them.
 // Pointers and interfaces will be dereferenced first.
 func IsFalsyOrEmptyList(v reflect.Value) bool {
 	v = resolve(v)
@@ -91,35 +167,32 @@ func IsFalsyOrEmptyList(v reflect.Value) bool {
 		return v.Uint() == 0
 	case reflect.Array, reflect.Slice:
 		return v.Len() == 0
-	case reflect.Pointer, reflect.Interface:
+	case reflect.Map:
+		return isEntryMap(v.Type()) && v.Len() == 0
+	case reflect.Func:
+		if v.IsNil() {
+			return true
+		}
+		switch t := v.Type(); {
+		case t.CanSeq2():
+			for range v.Seq2() {
+				return false
+			}
+			return true
+		case t.CanSeq():
+			for range v.Seq() {
+				return false
+			}
+			return true
+		}
+		return false
+	case reflect.Pointer, reflect.Interface, reflect.Chan:
 		return v.IsNil()
 	default:
 		return false
 	}
 }
 
-// ForEach returns an iterator over v's elements
-// if v represents a slice or an array,
-// or an iterator that only yields v otherwise.
-// Pointers and interfaces will be dereferenced first.
-func ForEach(v reflect.Value) iter.Seq[reflect.Value] {
-	return func(yield func(reflect.Value) bool) {
-		v := resolve(v)
-		if !IsFalsyOrEmptyList(v) {
-			switch v.Kind() {
-			case reflect.Array, reflect.Slice:
-				for i := range v.Len() {
-					if !yield(v.Index(i)) {
-						return
-					}
-				}
-			default:
-				yield(v)
-			}
-		}
-	}
-}
-
 func resolve(v reflect.Value) reflect.Value {
 	for {
 		k := v.Kind()
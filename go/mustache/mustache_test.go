@@ -4,8 +4,13 @@
 package mustache
 
 import (
+	"fmt"
+	"iter"
+	"maps"
 	"reflect"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestLookup(t *testing.T) {
@@ -15,3 +20,79 @@ func TestLookup(t *testing.T) {
 		t.Errorf("Lookup(...) = %#v", got)
 	}
 }
+
+func TestToString(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{"foo", "foo"},
+		{true, "true"},
+		{42, "42"},
+		{uint8(7), "7"},
+		{1.5, "1.5"},
+		{float32(0.1), "0.1"},
+		{1e21, "1e+21"},
+		{nil, ""},
+		{[]int{1, 2}, "[1 2]"},
+		{time.Second, "1s"},
+	}
+	for _, test := range tests {
+		if got := ToString(reflect.ValueOf(test.v)); got != test.want {
+			t.Errorf("ToString(reflect.ValueOf(%#v)) = %q; want %q", test.v, got, test.want)
+		}
+		if got, want := ToString(reflect.ValueOf(test.v)), fmt.Sprint(test.v); test.v != nil && got != want {
+			t.Errorf("ToString(reflect.ValueOf(%#v)) = %q; fmt.Sprint = %q", test.v, got, want)
+		}
+	}
+}
+
+func TestStrict(t *testing.T) {
+	v := reflect.ValueOf(0)
+	if got := Strict(v, "n"); got != v {
+		t.Errorf("Strict(%v, \"n\") = %v", v, got)
+	}
+
+	defer func() {
+		err, ok := recover().(*MissingError)
+		if !ok || err.Name != "a.b" {
+			t.Errorf("Strict(reflect.Value{}, \"a.b\") panicked with %#v; want *MissingError for a.b", err)
+		}
+	}()
+	Strict(reflect.Value{}, "a.b")
+	t.Error("Strict(reflect.Value{}, \"a.b\") did not panic")
+}
+
+func TestIsFalsyOrEmptyList(t *testing.T) {
+	closedChan := make(chan int)
+	close(closedChan)
+	tests := []struct {
+		v    any
+		want bool
+	}{
+		{nil, true},
+		{"", true},
+		{"x", false},
+		{0.0, true},
+		{[]int{}, true},
+		{[]int{0}, false},
+		{map[string]int{}, false},
+		{map[int]int{}, true},
+		{map[int]int{1: 1}, false},
+		{(chan int)(nil), true},
+		{make(chan int), false},
+		// Closed channels are truthy because checking for a value would consume it.
+		{closedChan, false},
+		{iter.Seq[int](nil), true},
+		{slices.Values([]int{}), true},
+		{slices.Values([]int{0}), false},
+		{maps.All(map[int]int{}), true},
+		{maps.All(map[int]int{1: 1}), false},
+		{func() {}, false},
+	}
+	for _, test := range tests {
+		if got := IsFalsyOrEmptyList(reflect.ValueOf(test.v)); got != test.want {
+			t.Errorf("IsFalsyOrEmptyList(reflect.ValueOf(%#v)) = %t; want %t", test.v, got, test.want)
+		}
+	}
+}
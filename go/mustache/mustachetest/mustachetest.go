@@ -0,0 +1,151 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+// Package mustachetest provides snapshot tests for functions generated by mustache-codegen.
+//
+// [Run] renders a template function with the data in each fixture file
+// matching testdata/*.json and compares the output
+// with the golden file of the same name ending in ".golden":
+//
+//	func TestPage(t *testing.T) {
+//		mustachetest.Run(t, Page, nil)
+//	}
+//
+// Fixture data is decoded into the type of the function's data parameter,
+// so functions generated with -go-type can be tested too.
+// Running "go test -mustachetest.update" writes the output to the golden files instead.
+// The flag is namespaced so that it does not collide with an -update flag
+// defined by the test package.
+package mustachetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("mustachetest.update", false, "update the golden files of mustachetest snapshot tests")
+
+// Options configures [Run].
+type Options struct {
+	// Pattern is the [filepath.Match] pattern of the fixture files.
+	// The default is "testdata/*.json".
+	Pattern string
+	// NormalizeHTML compares output with [NormalizeHTML] applied,
+	// so that changes to indentation and line breaks do not fail the test.
+	// Golden files are written as rendered.
+	NormalizeHTML bool
+	// Update writes the golden files instead of comparing against them,
+	// as if -mustachetest.update was given.
+	Update bool
+}
+
+// Case is a snapshot test: a fixture and its golden file.
+type Case struct {
+	// Name is the name of the fixture file without its extension.
+	Name string
+	Data json.RawMessage
+	// Golden is the path of the golden file.
+	Golden string
+}
+
+// Cases returns the snapshot tests for the fixture files matching pattern,
+// ordered by name.
+func Cases(pattern string) ([]*Case, error) {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var cases []*Case
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		base := strings.TrimSuffix(file, filepath.Ext(file))
+		cases = append(cases, &Case{
+			Name:   filepath.Base(base),
+			Data:   data,
+			Golden: base + ".golden",
+		})
+	}
+	return cases, nil
+}
+
+// Run runs a subtest for each fixture that renders it with render
+// and compares the output with the fixture's golden file.
+// A nil opts is equivalent to a zero [Options].
+// It fails the test if there are no fixtures.
+func Run[T any](t *testing.T, render func(*bytes.Buffer, T), opts *Options) {
+	t.Helper()
+	if opts == nil {
+		opts = new(Options)
+	}
+	pattern := opts.Pattern
+	if pattern == "" {
+		pattern = filepath.Join("testdata", "*.json")
+	}
+	cases, err := Cases(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatalf("no fixtures match %s", pattern)
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			check(t, c, render, opts)
+		})
+	}
+}
+
+// check renders a single snapshot test.
+func check[T any](tb testing.TB, c *Case, render func(*bytes.Buffer, T), opts *Options) {
+	tb.Helper()
+	var data T
+	if err := json.Unmarshal(c.Data, &data); err != nil {
+		tb.Fatalf("%s: %v", c.Name, err)
+	}
+	buf := new(bytes.Buffer)
+	render(buf, data)
+	got := buf.String()
+
+	if *update || opts.Update {
+		if err := os.WriteFile(c.Golden, buf.Bytes(), 0o666); err != nil {
+			tb.Fatal(err)
+		}
+		return
+	}
+	golden, err := os.ReadFile(c.Golden)
+	if os.IsNotExist(err) {
+		tb.Fatalf("%s does not exist; run go test -mustachetest.update to create it", c.Golden)
+	}
+	if err != nil {
+		tb.Fatal(err)
+	}
+	want := string(golden)
+	if opts.NormalizeHTML {
+		got = NormalizeHTML(got)
+		want = NormalizeHTML(want)
+	}
+	if got != want {
+		tb.Errorf("output:\n%q\nexpected (%s):\n%q", got, c.Golden, want)
+	}
+}
+
+var htmlSpace = regexp.MustCompile(`\s+`)
+
+// NormalizeHTML collapses each run of whitespace in s into a single space,
+// removes whitespace next to tags, and trims whitespace from both ends.
+// Unlike a browser, it does not preserve whitespace in elements like <pre>.
+func NormalizeHTML(s string) string {
+	s = htmlSpace.ReplaceAllString(s, " ")
+	s = strings.ReplaceAll(s, "> ", ">")
+	s = strings.ReplaceAll(s, " <", "<")
+	return strings.TrimSpace(s)
+}
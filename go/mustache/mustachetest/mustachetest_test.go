@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package mustachetest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/kagisearch/mustache-codegen/go/mustache"
+	"github.com/kagisearch/mustache-codegen/go/mustache/interp"
+)
+
+// Test packages that import mustachetest may define their own -update flag.
+var _ = flag.Bool("update", false, "update golden files")
+
+type page struct {
+	Title string
+	Items []string
+}
+
+// renderPage has the signature of a function generated with -go-type=page.
+func renderPage(buf *bytes.Buffer, data page) {
+	fmt.Fprintf(buf, "<h1>%s</h1>\n<ul>\n", data.Title)
+	for _, item := range data.Items {
+		buf.WriteString("  <li>")
+		mustache.EscapeMinimal.Escape(buf, item)
+		buf.WriteString("</li>\n")
+	}
+	buf.WriteString("</ul>\n")
+}
+
+func writeFixtures(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRun(t *testing.T) {
+	dir := writeFixtures(t, map[string]string{
+		"empty.json": `{"Title": "Empty"}`,
+		"items.json": `{"Title": "Items", "Items": ["a", "<b>"]}`,
+	})
+	opts := &Options{Pattern: filepath.Join(dir, "*.json"), Update: true}
+	Run(t, renderPage, opts)
+	golden, err := os.ReadFile(filepath.Join(dir, "items.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = "<h1>Items</h1>\n<ul>\n  <li>a</li>\n  <li>&lt;b&gt;</li>\n</ul>\n"
+	if string(golden) != want {
+		t.Errorf("items.golden = %q; want %q", golden, want)
+	}
+
+	opts.Update = false
+	Run(t, renderPage, opts)
+}
+
+func TestRunInterpreted(t *testing.T) {
+	tmpl, err := interp.Parse("{{#items}}<li>{{.}}</li>{{/items}}", func(name string) (string, error) { return "", nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := writeFixtures(t, map[string]string{
+		"list.json":   `{"items": [1, 2.5]}`,
+		"list.golden": "<li>1</li>\n<li>2.5</li>\n",
+	})
+	Run(t, tmpl.Render, &Options{Pattern: filepath.Join(dir, "*.json"), NormalizeHTML: true})
+}
+
+// recorder is a [testing.TB] that records failures instead of failing the test.
+// Like [testing.T], its Fatal methods stop the goroutine that calls them.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatal(args ...any) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+	runtime.Goexit()
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	runtime.Goexit()
+}
+
+func TestCheckFailure(t *testing.T) {
+	dir := writeFixtures(t, map[string]string{
+		"changed.golden": "<h1>Old</h1>\n<ul>\n</ul>\n",
+		"spaced.golden":  "<h1>Spaced</h1> <ul></ul>",
+	})
+	tests := []struct {
+		name  string
+		opts  Options
+		title string
+		want  string
+	}{
+		{name: "changed", title: "New", want: "expected"},
+		{name: "spaced", title: "Spaced", want: "expected"},
+		{name: "spaced", title: "Spaced", opts: Options{NormalizeHTML: true}},
+		{name: "missing", title: "Missing", want: "run go test -mustachetest.update"},
+	}
+	for _, test := range tests {
+		c := &Case{
+			Name:   test.name,
+			Data:   []byte(fmt.Sprintf(`{"Title": %q}`, test.title)),
+			Golden: filepath.Join(dir, test.name+".golden"),
+		}
+		r := &recorder{TB: t}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			check(r, c, renderPage, &test.opts)
+		}()
+		<-done
+		switch {
+		case test.want == "" && len(r.errors) > 0:
+			t.Errorf("%s: unexpected failure: %s", test.name, r.errors[0])
+		case test.want != "" && (len(r.errors) != 1 || !strings.Contains(r.errors[0], test.want)):
+			t.Errorf("%s: failures = %q; want one containing %q", test.name, r.errors, test.want)
+		}
+	}
+}
+
+func TestNormalizeHTML(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{"", ""},
+		{"  <p>\n    Hello,\n    world!\n  </p>\n", "<p>Hello, world!</p>"},
+		{"<ul>\n\t<li>a</li>\n\t<li>b</li>\n</ul>", "<ul><li>a</li><li>b</li></ul>"},
+		{"a  b", "a b"},
+	}
+	for _, test := range tests {
+		if got := NormalizeHTML(test.s); got != test.want {
+			t.Errorf("NormalizeHTML(%q) = %q; want %q", test.s, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package mustache
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+)
+
+// Stack is the state of a template function call:
+// the context stack that names are looked up in
+// and the block arguments that are in scope.
+// Stacks are obtained with [GetStack] and should be returned with [PutStack]
+// so that their memory can be reused by later calls.
+type Stack struct {
+	values     []reflect.Value
+	blocks     []blockFrame
+	translator Translator
+	// stops holds the stop functions of the iterators returned by [Stack.Iterate].
+	// The functions of closed iterators are nil.
+	stops []func()
+}
+
+var stackPool = sync.Pool{
+	New: func() any { return new(Stack) },
+}
+
+// GetStack returns a [Stack] from a shared pool
+// whose context stack only contains data.
+func GetStack(data any) *Stack {
+	s := stackPool.Get().(*Stack)
+	s.values = append(s.values, reflect.ValueOf(data))
+	return s
+}
+
+// PutStack clears s and returns it to the pool used by [GetStack].
+// It closes the iterators returned by [Stack.Iterate] that are still open,
+// as happens when a template function panics in a section.
+// s must not be used after calling PutStack.
+func PutStack(s *Stack) {
+	for i := len(s.stops) - 1; i >= 0; i-- {
+		if s.stops[i] != nil {
+			s.stops[i]()
+		}
+	}
+	clear(s.stops)
+	s.stops = s.stops[:0]
+	clear(s.values)
+	s.values = s.values[:0]
+	clear(s.blocks)
+	s.blocks = s.blocks[:0]
+	s.translator = nil
+	stackPool.Put(s)
+}
+
+// Push pushes v onto the context stack.
+func (s *Stack) Push(v reflect.Value) {
+	s.values = append(s.values, v)
+}
+
+// Pop removes the value most recently pushed onto the context stack.
+func (s *Stack) Pop() {
+	s.values[len(s.values)-1] = reflect.Value{}
+	s.values = s.values[:len(s.values)-1]
+}
+
+// Top returns the value most recently pushed onto the context stack.
+func (s *Stack) Top() reflect.Value {
+	return s.values[len(s.values)-1]
+}
+
+// Iterate is like the package-level [Iterate] function,
+// but the returned iterator is closed by [PutStack] if it is still open.
+func (s *Stack) Iterate(v reflect.Value) Iterator {
+	it := Iterate(v)
+	if it.stop != nil {
+		it.stack = s
+		it.stopIndex = len(s.stops)
+		s.stops = append(s.stops, it.stop)
+	}
+	return it
+}
+
+// closeStop clears the stop function at index i of s.stops
+// and drops the cleared functions at the end of the list.
+// Iterators are normally closed in the reverse order of their creation,
+// so the list does not grow while iterating over nested sections.
+func (s *Stack) closeStop(i int) {
+	if i >= len(s.stops) {
+		return
+	}
+	s.stops[i] = nil
+	n := len(s.stops)
+	for n > 0 && s.stops[n-1] == nil {
+		n--
+	}
+	s.stops = s.stops[:n]
+}
+
+// Lookup is like the package-level [Lookup] function,
+// but takes a name that has already been split at its dots.
+// If no parts are given, then Lookup returns the top of the context stack.
+func (s *Stack) Lookup(parts ...string) reflect.Value {
+	if len(parts) == 0 {
+		return s.Top()
+	}
+	return lookupParts(s.values, parts)
+}
+
+// BlockFunc is the type of a function that renders a block argument.
+// blocks is the set of block arguments in scope where the block argument was defined.
+type BlockFunc func(buf *bytes.Buffer, indent string, stack *Stack, blocks Blocks)
+
+// BlockTable is the type of a function that returns the block argument
+// defined in a parent tag with the given name
+// or nil if the parent tag does not define such a block argument.
+type BlockTable func(name string) BlockFunc
+
+// Blocks is a handle to a set of block arguments
+// that have been pushed onto a [Stack].
+// The zero value is an empty set.
+type Blocks struct {
+	// i is one more than the index of the set's frame in Stack.blocks,
+	// so that zero represents the empty set.
+	i int
+}
+
+type blockFrame struct {
+	outer Blocks
+	table BlockTable
+}
+
+// PushBlocks pushes the block arguments in table onto s.
+// It returns a handle to a set of block arguments
+// that consists of the arguments in outer
+// along with the arguments in table that are not present in outer.
+// The handle is valid until the corresponding call to [Stack.PopBlocks].
+func (s *Stack) PushBlocks(outer Blocks, table BlockTable) Blocks {
+	s.blocks = append(s.blocks, blockFrame{outer: outer, table: table})
+	return Blocks{len(s.blocks)}
+}
+
+// PopBlocks removes the block arguments most recently pushed by [Stack.PushBlocks].
+func (s *Stack) PopBlocks() {
+	s.blocks[len(s.blocks)-1] = blockFrame{}
+	s.blocks = s.blocks[:len(s.blocks)-1]
+}
+
+// Block returns the block argument with the given name from blocks,
+// along with the set of block arguments that should be passed to it.
+// Arguments from outer sets take precedence over inner sets.
+// If there is no such block argument, Block returns nil.
+func (s *Stack) Block(blocks Blocks, name string) (BlockFunc, Blocks) {
+	var f BlockFunc
+	var env Blocks
+	for b := blocks; b.i > 0; {
+		frame := s.blocks[b.i-1]
+		if g := frame.table(name); g != nil {
+			f, env = g, frame.outer
+		}
+		b = frame.outer
+	}
+	return f, env
+}
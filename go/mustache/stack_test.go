@@ -0,0 +1,85 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package mustache
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestStackLookup(t *testing.T) {
+	s := GetStack(map[string]any{
+		"a": map[string]any{"b": "outer"},
+		"c": "root",
+		"n": nil,
+	})
+	defer PutStack(s)
+	s.Push(reflect.ValueOf(map[string]any{"a": map[string]any{}, "n2": nil}))
+
+	if got := ToString(s.Lookup("c")); got != "root" {
+		t.Errorf(`s.Lookup("c") = %q; want "root"`, got)
+	}
+	// Dotted names resolve the first part in the nearest context.
+	if got := s.Lookup("a", "b"); got.IsValid() {
+		t.Errorf(`s.Lookup("a", "b") = %v; want invalid value`, got)
+	}
+	// Keys with nil values are found.
+	if got := s.Lookup("n2"); !got.IsValid() || !IsFalsyOrEmptyList(got) {
+		t.Errorf(`s.Lookup("n2") = %v; want valid nil value`, got)
+	}
+	s.Pop()
+	if got := ToString(s.Lookup("a", "b")); got != "outer" {
+		t.Errorf(`s.Lookup("a", "b") = %q; want "outer"`, got)
+	}
+}
+
+func TestStackBlock(t *testing.T) {
+	render := func(s string) BlockFunc {
+		return func(buf *bytes.Buffer, indent string, stack *Stack, blocks Blocks) {
+			buf.WriteString(s)
+		}
+	}
+	inner := func(name string) BlockFunc {
+		switch name {
+		case "a":
+			return render("inner a")
+		case "b":
+			return render("inner b")
+		}
+		return nil
+	}
+	outer := func(name string) BlockFunc {
+		if name == "a" {
+			return render("outer a")
+		}
+		return nil
+	}
+
+	s := GetStack(nil)
+	defer PutStack(s)
+	outerBlocks := s.PushBlocks(Blocks{}, outer)
+	innerBlocks := s.PushBlocks(outerBlocks, inner)
+	for _, test := range []struct {
+		name    string
+		want    string
+		wantEnv Blocks
+	}{
+		{"a", "outer a", Blocks{}},
+		{"b", "inner b", outerBlocks},
+		{"c", "", Blocks{}},
+	} {
+		f, env := s.Block(innerBlocks, test.name)
+		got := new(bytes.Buffer)
+		if f != nil {
+			f(got, "", s, env)
+		}
+		if got.String() != test.want || env != test.wantEnv {
+			t.Errorf("s.Block(innerBlocks, %q) rendered %q with %v; want %q with %v",
+				test.name, got, env, test.want, test.wantEnv)
+		}
+	}
+	s.PopBlocks()
+	s.PopBlocks()
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package mustache
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Translator translates the messages in {{#_i18n}} sections of templates.
+type Translator interface {
+	// Translate returns the translation of message.
+	// Messages contain variables written as {{name}} or {{&name}},
+	// which are rendered in the translation in the same way as in a template.
+	// Translate should return message if it has no translation.
+	Translate(message string) string
+}
+
+// SetTranslator sets the [Translator] that [Stack.Translate] uses.
+// A nil Translator renders messages untranslated.
+func (s *Stack) SetTranslator(tr Translator) {
+	s.translator = tr
+}
+
+// Translate renders the translation of message to buf,
+// looking up its variables in s and escaping {{name}} variables with e.
+// Text in the translation that is not a variable tag is written as is.
+func (s *Stack) Translate(buf *bytes.Buffer, e Escaper, message string) {
+	if s.translator != nil {
+		message = s.translator.Translate(message)
+	}
+	for {
+		start := strings.Index(message, "{{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(message[start:], "}}")
+		if end < 0 {
+			break
+		}
+		end += start
+		buf.WriteString(message[:start])
+		name, raw := strings.CutPrefix(message[start+len("{{"):end], "&")
+		name = strings.TrimSpace(name)
+		var v string
+		if name == "." {
+			v = ToString(s.Top())
+		} else {
+			v = ToString(lookupParts(s.values, strings.Split(name, ".")))
+		}
+		if raw {
+			buf.WriteString(v)
+		} else {
+			e.Escape(buf, v)
+		}
+		message = message[end+len("}}"):]
+	}
+	buf.WriteString(message)
+}
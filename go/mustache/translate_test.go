@@ -0,0 +1,53 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package mustache
+
+import (
+	"bytes"
+	"testing"
+)
+
+type mapTranslator map[string]string
+
+func (tr mapTranslator) Translate(message string) string {
+	if t, ok := tr[message]; ok {
+		return t
+	}
+	return message
+}
+
+func TestTranslate(t *testing.T) {
+	data := map[string]any{
+		"name": "<Ada>",
+		"user": map[string]any{"count": 3},
+	}
+	tr := mapTranslator{
+		"Hello, {{name}}!":        "Bonjour, {{ name }} !",
+		"{{user.count}} messages": "{{&user.count}} messages",
+		"Raw {{&name}}":           "Brut {{&name}}",
+		"Broken":                  "{{name} {{",
+	}
+	tests := []struct {
+		tr      Translator
+		message string
+		want    string
+	}{
+		{nil, "Hello, {{name}}!", "Hello, &lt;Ada&gt;!"},
+		{tr, "Hello, {{name}}!", "Bonjour, &lt;Ada&gt; !"},
+		{tr, "{{user.count}} messages", "3 messages"},
+		{tr, "Raw {{&name}}", "Brut <Ada>"},
+		{tr, "Untranslated {{missing}}.", "Untranslated ."},
+		{tr, "Broken", "{{name} {{"},
+	}
+	for _, test := range tests {
+		s := GetStack(data)
+		s.SetTranslator(test.tr)
+		buf := new(bytes.Buffer)
+		s.Translate(buf, EscapeMinimal, test.message)
+		PutStack(s)
+		if got := buf.String(); got != test.want {
+			t.Errorf("Translate(%q) with %v = %q; want %q", test.message, test.tr, got, test.want)
+		}
+	}
+}
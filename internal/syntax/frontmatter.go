@@ -0,0 +1,175 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package syntax
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// frontMatterDelim is the line that starts and ends a template's front matter.
+const frontMatterDelim = "---"
+
+// MetaField is a field of a template's front matter.
+type MetaField struct {
+	Key string
+	// Value is a string, a bool, or a json.Number.
+	Value any
+}
+
+// cutFrontMatter splits a template that starts with a front matter block
+// into the block's content and the rest of the template,
+// also returning the number of lines the block occupies.
+// ok is false if s does not start with a closed front matter block.
+func cutFrontMatter(s string) (content, rest string, lines int, ok bool) {
+	eol := indexNextLine(s)
+	if strings.TrimRight(s[:eol], "\r\n") != frontMatterDelim || eol == len(s) {
+		return "", s, 0, false
+	}
+	start := eol
+	lines = 1
+	for i := start; i < len(s); {
+		eol := i + indexNextLine(s[i:])
+		lines++
+		if strings.TrimRight(s[i:eol], "\r\n") == frontMatterDelim {
+			return s[start:i], s[eol:], lines, true
+		}
+		i = eol
+	}
+	return "", s, 0, false
+}
+
+// ParseFrontMatter parses the content of a template's front matter block:
+// either a JSON object or lines of "key: value" pairs.
+// Values must be strings, numbers, or booleans.
+// In the "key: value" form, a value is a number, a boolean,
+// or a double-quoted string if it parses as one,
+// and otherwise the text after the colon.
+// Blank lines and lines starting with "#" are ignored.
+// Errors are prefixed with the line number relative to the start of s.
+func ParseFrontMatter(s string) ([]MetaField, error) {
+	return parseFrontMatter(s, 1)
+}
+
+// parseFrontMatter is like [ParseFrontMatter],
+// but numbers the lines of s starting at firstLine.
+func parseFrontMatter(s string, firstLine int) ([]MetaField, error) {
+	var fields []MetaField
+	add := func(lineno int, key string, value any) error {
+		if !isMetaKey(key) {
+			return fmt.Errorf("%d: invalid front matter key %q", lineno, key)
+		}
+		for _, f := range fields {
+			if f.Key == key {
+				return fmt.Errorf("%d: front matter key %s declared more than once", lineno, key)
+			}
+		}
+		fields = append(fields, MetaField{Key: key, Value: value})
+		return nil
+	}
+
+	if strings.HasPrefix(strings.TrimSpace(s), "{") {
+		if err := parseJSONFrontMatter(s, firstLine, add); err != nil {
+			return nil, err
+		}
+		return fields, nil
+	}
+	for i, line := range strings.Split(s, "\n") {
+		lineno := firstLine + i
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("%d: front matter line is not key: value", lineno)
+		}
+		if err := add(lineno, strings.TrimSpace(key), metaValue(strings.TrimSpace(value))); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+// parseJSONFrontMatter parses front matter that is a JSON object,
+// calling add for each of its fields in order.
+func parseJSONFrontMatter(s string, firstLine int, add func(lineno int, key string, value any) error) error {
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+	lineAt := func(offset int64) int {
+		return firstLine + strings.Count(s[:offset], "\n")
+	}
+	wrap := func(err error) error {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return fmt.Errorf("%d: front matter: %v", lineAt(syntaxErr.Offset), err)
+		}
+		return fmt.Errorf("%d: front matter: %v", lineAt(d.InputOffset()), err)
+	}
+	if _, err := d.Token(); err != nil {
+		return wrap(err)
+	}
+	for d.More() {
+		tok, err := d.Token()
+		if err != nil {
+			return wrap(err)
+		}
+		key := tok.(string)
+		lineno := lineAt(d.InputOffset())
+		var value any
+		if err := d.Decode(&value); err != nil {
+			return wrap(err)
+		}
+		switch value.(type) {
+		case string, bool, json.Number:
+		default:
+			return fmt.Errorf("%d: front matter value %s is not a string, number, or boolean", lineno, key)
+		}
+		if err := add(lineno, key, value); err != nil {
+			return err
+		}
+	}
+	if _, err := d.Token(); err != nil {
+		return wrap(err)
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return fmt.Errorf("%d: front matter has text after the JSON object", lineAt(d.InputOffset()))
+	}
+	return nil
+}
+
+// metaValue returns the value of a "key: value" front matter line.
+func metaValue(s string) any {
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if strings.HasPrefix(s, `"`) {
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			return unquoted
+		}
+	}
+	if s != "" && (s[0] == '-' || '0' <= s[0] && s[0] <= '9') && json.Valid([]byte(s)) {
+		return json.Number(s)
+	}
+	return s
+}
+
+// isMetaKey reports whether key is a valid front matter key:
+// a letter followed by letters, digits, "_", or "-".
+func isMetaKey(key string) bool {
+	for i, c := range key {
+		isLetter := 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+		if !isLetter && (i == 0 || !('0' <= c && c <= '9' || c == '_' || c == '-')) {
+			return false
+		}
+	}
+	return key != ""
+}
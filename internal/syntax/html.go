@@ -0,0 +1,239 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package syntax
+
+import "strings"
+
+// htmlRawElements is the set of elements whose content
+// [MinifyHTML] copies without changes.
+var htmlRawElements = map[string]bool{
+	"pre":      true,
+	"textarea": true,
+	"script":   true,
+	"style":    true,
+}
+
+// MinifyHTML returns a copy of tags with the HTML in its [Literal] tags minified.
+// Runs of whitespace in text and between attributes
+// are collapsed into a single newline (if the run contains one) or space,
+// and comments (other than conditional comments like <!--[if IE]>) are removed
+// along with the variables inside them.
+// The content of <pre>, <textarea>, <script>, and <style> elements
+// and quoted attribute values are kept as they are.
+//
+// Since indentation is whitespace at the beginning of a line,
+// MinifyHTML also removes [IndentPoint] tags and the indentation of partials
+// outside of those elements.
+// The literals are processed in the order they appear in the template,
+// so HTML constructs may span Mustache tags.
+func MinifyHTML(tags []Tag) []Tag {
+	return new(htmlMinifier).minify(tags)
+}
+
+// MinifyHTMLPartial is like [MinifyHTML], but keeps all [IndentPoint] tags,
+// since a partial may be included with indentation inside a <pre> or <textarea> element.
+// Outside of such elements, the indentation of the partial is removed
+// where it is included, so the indent points have no effect.
+func MinifyHTMLPartial(tags []Tag) []Tag {
+	return (&htmlMinifier{partial: true}).minify(tags)
+}
+
+// htmlMinifier holds the state of [MinifyHTML]
+// between the literals in a template.
+type htmlMinifier struct {
+	// comment is whether the text is inside a comment.
+	comment bool
+	// tag is whether the text is inside a start or end tag.
+	tag bool
+	// quote is the quote character of the attribute value
+	// the text is inside, or zero.
+	quote byte
+	// tagRaw is the name of the raw element the current start tag opens, if any.
+	tagRaw string
+	// raw is the name of the raw element the text is inside, if any.
+	raw string
+	// partial is whether to keep indent points outside of raw elements.
+	partial bool
+}
+
+func (h *htmlMinifier) minify(tags []Tag) []Tag {
+	result := make([]Tag, 0, len(tags))
+	for i := 0; i < len(tags); i++ {
+		t := tags[i]
+		switch t.Type {
+		case Literal, IndentPoint:
+			// Minify adjacent literals together
+			// so that runs of whitespace between them collapse.
+			sb := new(strings.Builder)
+			var points []int
+			for ; i < len(tags) && (tags[i].Type == Literal || tags[i].Type == IndentPoint); i++ {
+				if tags[i].Type == IndentPoint {
+					points = append(points, sb.Len())
+				}
+				sb.WriteString(tags[i].S)
+			}
+			i--
+			s, points := h.minifyText(sb.String(), points)
+			prev := 0
+			for _, p := range points {
+				if p > prev {
+					result = append(result, Tag{Type: Literal, S: s[prev:p]})
+				}
+				result = append(result, Tag{Type: IndentPoint})
+				prev = p
+			}
+			if prev < len(s) {
+				result = append(result, Tag{Type: Literal, S: s[prev:]})
+			}
+		case Variable, RawVariable:
+			if !h.comment {
+				result = append(result, t)
+			}
+		default:
+			if h.raw == "" {
+				t.Indent = ""
+			}
+			t.Body = h.minify(t.Body)
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// minifyText minifies s, the text of adjacent literals.
+// points are the offsets of indent points in s in increasing order,
+// and minifyText returns their offsets in the result.
+// Indent points in the same run of collapsed whitespace are merged.
+func (h *htmlMinifier) minifyText(s string, points []int) (string, []int) {
+	sb := new(strings.Builder)
+	var newPoints []int
+	// mapPoints maps the points before n to the offset given by pos.
+	mapPoints := func(n int, pos func(p int) int) {
+		for ; len(points) > 0 && points[0] < n; points = points[1:] {
+			if h.raw == "" && !h.partial {
+				continue
+			}
+			if p := pos(points[0]); len(newPoints) == 0 || newPoints[len(newPoints)-1] != p {
+				newPoints = append(newPoints, p)
+			}
+		}
+	}
+	// write writes s[i:n] to the result unchanged.
+	write := func(i, n int) {
+		mapPoints(n, func(p int) int { return sb.Len() + p - i })
+		sb.WriteString(s[i:n])
+	}
+	// end maps the points before n to the end of the result.
+	end := func(n int) {
+		mapPoints(n, func(int) int { return sb.Len() })
+	}
+	// skip removes the points before n, which are in removed text.
+	skip := func(n int) {
+		for len(points) > 0 && points[0] < n {
+			points = points[1:]
+		}
+	}
+	for i := 0; i < len(s); {
+		switch {
+		case h.comment:
+			n := strings.Index(s[i:], "-->")
+			if n < 0 {
+				i = len(s)
+				skip(i + 1)
+				break
+			}
+			i += n + len("-->")
+			skip(i)
+			h.comment = false
+		case h.quote != 0:
+			n := strings.IndexByte(s[i:], h.quote)
+			if n < 0 {
+				write(i, len(s))
+				i = len(s)
+				break
+			}
+			write(i, i+n+1)
+			i += n + 1
+			h.quote = 0
+		case h.raw != "" && !h.tag:
+			n := indexFold(s[i:], "</"+h.raw)
+			if n < 0 {
+				write(i, len(s))
+				i = len(s)
+				break
+			}
+			write(i, i+n)
+			i += n
+			// The indentation of the end tag is inside the element.
+			end(i + 1)
+			h.raw = ""
+		case isHTMLSpace(s[i]):
+			n := i
+			for n < len(s) && isHTMLSpace(s[n]) {
+				n++
+			}
+			// An indent point at the start of the run stays before it.
+			end(i + 1)
+			if strings.Contains(s[i:n], "\n") {
+				sb.WriteString("\n")
+			} else {
+				sb.WriteString(" ")
+			}
+			end(n)
+			i = n
+		case h.tag:
+			switch s[i] {
+			case '"', '\'':
+				h.quote = s[i]
+			case '>':
+				h.tag = false
+				h.raw = h.tagRaw
+				h.tagRaw = ""
+			}
+			write(i, i+1)
+			i++
+		case strings.HasPrefix(s[i:], "<!--") && !strings.HasPrefix(s[i:], "<!--["):
+			end(i + 1)
+			h.comment = true
+			i += len("<!--")
+			skip(i)
+		case s[i] == '<' && i+1 < len(s) && (isHTMLNameStart(s[i+1]) || s[i+1] == '/'):
+			h.tag = true
+			if s[i+1] != '/' {
+				n := i + 1
+				for n < len(s) && (isHTMLNameStart(s[n]) || '0' <= s[n] && s[n] <= '9') {
+					n++
+				}
+				if name := strings.ToLower(s[i+1 : n]); htmlRawElements[name] && (n == len(s) || s[n] == '>' || s[n] == '/' || isHTMLSpace(s[n])) {
+					h.tagRaw = name
+				}
+			}
+			write(i, i+1)
+			i++
+		default:
+			write(i, i+1)
+			i++
+		}
+	}
+	end(len(s) + 1)
+	return sb.String(), newPoints
+}
+
+// indexFold is like [strings.Index], but ignores ASCII case.
+func indexFold(s, substr string) int {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if strings.EqualFold(s[i:i+len(substr)], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isHTMLNameStart(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
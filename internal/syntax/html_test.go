@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package syntax
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMinifyHTML(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"", ""},
+		{"<p>a   b</p>", "<p>a b</p>"},
+		{"<div>\n    <p>a</p>\n\n  </div>\n", "<div>\n<p>a</p>\n</div>\n"},
+		{"<p   class=\"a  b\"\n  id='x  y'>", "<p class=\"a  b\"\nid='x  y'>"},
+		{"a<!-- comment -->b", "ab"},
+		{"a<!--[if IE]>  x  <![endif]-->b", "a<!--[if IE]> x <![endif]-->b"},
+		{"<pre>\n  a  b\n</pre>  c", "<pre>\n{{INDENT}}  a  b\n{{INDENT}}</pre> c"},
+		{"<PRE class=x>  a  </Pre>  b", "<PRE class=x>  a  </Pre> b"},
+		{"<textarea>  a  </textarea><script>if (a  <  b) {}</script>", "<textarea>  a  </textarea><script>if (a  <  b) {}</script>"},
+		{"<prefix>  a  </prefix>", "<prefix> a </prefix>"},
+
+		// Mustache tags.
+		{"<p>  {{a}}  </p>", "<p> {{a}} </p>"},
+		{"<ul>\n  {{#items}}\n  <li>  {{.}}</li>\n  {{/items}}\n</ul>", "<ul>\n{{#items}} <li> {{.}}</li>\n{{/items}}</ul>"},
+		{"<!-- {{a}} -->{{b}}", "{{b}}"},
+		{"<pre>  {{a}}  </pre>  {{b}}  ", "<pre>  {{a}}  </pre> {{b}} "},
+		{"<a title=\"{{a}}  b\"  href=x>", "<a title=\"{{a}}  b\" href=x>"},
+		{"<div>\n  {{>item}}\n</div>", "<div>\n{{>item}}</div>"},
+		{"<pre>\n  {{>item}}\n</pre>\n  {{>item}}\n", "<pre>\n{{>item  }}{{INDENT}}</pre>\n{{>item}}"},
+		{"<textarea>\n  {{#a}}\n  {{>item}}\n  {{/a}}\n</textarea>", "<textarea>\n{{#a}}{{>item  }}{{/a}}{{INDENT}}</textarea>"},
+	}
+	for _, test := range tests {
+		tags, err := Parse(test.source)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", test.source, err)
+			continue
+		}
+		sb := new(strings.Builder)
+		writeTestTags(sb, MinifyHTML(tags))
+		if got := sb.String(); got != test.want {
+			t.Errorf("MinifyHTML(Parse(%q)) = %q; want %q", test.source, got, test.want)
+		}
+	}
+}
+
+func TestMinifyHTMLPartial(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"", ""},
+		{"a\n\n\n  b", "{{INDENT}}a\n{{INDENT}}b"},
+		{"<ul>\n  <li>a</li>\n</ul>\n", "{{INDENT}}<ul>\n{{INDENT}}<li>a</li>\n{{INDENT}}</ul>\n"},
+		{"a<!--\n  b\n-->c", "{{INDENT}}ac"},
+		{"a\n<!-- b -->c", "{{INDENT}}a\n{{INDENT}}c"},
+		{"<pre>\n  a\n</pre>", "{{INDENT}}<pre>\n{{INDENT}}  a\n{{INDENT}}</pre>"},
+		{"<div>\n  {{>item}}\n</div>", "{{INDENT}}<div>\n{{>item}}{{INDENT}}</div>"},
+	}
+	for _, test := range tests {
+		tags, err := Parse(test.source)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", test.source, err)
+			continue
+		}
+		sb := new(strings.Builder)
+		writeTestTags(sb, MinifyHTMLPartial(tags))
+		if got := sb.String(); got != test.want {
+			t.Errorf("MinifyHTMLPartial(Parse(%q)) = %q; want %q", test.source, got, test.want)
+		}
+	}
+}
+
+// writeTestTags writes a Mustache template that represents tags,
+// marking indentation so that tests can detect it.
+func writeTestTags(sb *strings.Builder, tags []Tag) {
+	for _, t := range tags {
+		switch t.Type {
+		case Literal:
+			sb.WriteString(t.S)
+		case IndentPoint:
+			sb.WriteString("{{INDENT}}")
+		case Variable:
+			sb.WriteString("{{" + t.S + "}}")
+		case Section:
+			sb.WriteString("{{#" + t.S + "}}")
+			writeTestTags(sb, t.Body)
+			sb.WriteString("{{/" + t.S + "}}")
+		case Partial:
+			sb.WriteString("{{>" + t.S + t.Indent + "}}")
+		default:
+			sb.WriteString("{{?}}")
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package syntax
+
+import "fmt"
+
+// pragmaValues maps the names of the pragmas that templates can declare
+// to whether the pragma takes a value.
+var pragmaValues = map[string]bool{
+	// ESCAPE=SET is like -escape=SET.
+	"ESCAPE": true,
+	// GO_TYPE=TYPE is like -go-type=TYPE.
+	"GO_TYPE": true,
+	// STRICT is like -strict.
+	"STRICT": false,
+}
+
+// Pragmas returns the pragmas declared at the top level of a template
+// mapped to their values.
+// It returns an error if a pragma is unknown, declared more than once,
+// or is missing its value.
+func Pragmas(tags []Tag) (map[string]string, error) {
+	pragmas := make(map[string]string)
+	for _, t := range tags {
+		if t.Type != Pragma {
+			continue
+		}
+		takesValue, ok := pragmaValues[t.S]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("%d: unknown pragma %s", t.Line, t.S)
+		case takesValue && t.Value == "":
+			return nil, fmt.Errorf("%d: pragma %s requires a value", t.Line, t.S)
+		case !takesValue && t.Value != "":
+			return nil, fmt.Errorf("%d: pragma %s does not take a value", t.Line, t.S)
+		}
+		if _, dup := pragmas[t.S]; dup {
+			return nil, fmt.Errorf("%d: pragma %s declared more than once", t.Line, t.S)
+		}
+		pragmas[t.S] = t.Value
+	}
+	return pragmas, nil
+}
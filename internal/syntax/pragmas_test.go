@@ -0,0 +1,37 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package syntax
+
+import "testing"
+
+func TestPragmas(t *testing.T) {
+	tags, err := Parse("{{%STRICT}}\n{{% GO_TYPE=views.Page }}\nHello\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Pragmas(tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got["STRICT"] != "" || got["GO_TYPE"] != "views.Page" {
+		t.Errorf("Pragmas(...) = %q; want map[GO_TYPE:views.Page STRICT:]", got)
+	}
+
+	badSources := []string{
+		"{{%FOO}}",
+		"{{%ESCAPE}}",
+		"{{%STRICT=yes}}",
+		"{{%STRICT}}{{%STRICT}}",
+	}
+	for _, source := range badSources {
+		tags, err := Parse(source)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", source, err)
+			continue
+		}
+		if _, err := Pragmas(tags); err == nil {
+			t.Errorf("Pragmas(Parse(%q)) did not return an error", source)
+		}
+	}
+}
@@ -0,0 +1,708 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+// Package syntax parses Mustache templates into a tree of tags.
+// It is shared by the mustache-codegen code generators
+// and the template interpreter.
+package syntax
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Tag is a node in a parsed template.
+type Tag struct {
+	Type TagType
+	// S is the tag's key (e.g. the variable name or partial name)
+	// or the text of a [Literal] tag.
+	S      string
+	Indent string
+	// IndentArgument is whether to indent an argument block that replaces this parameter block.
+	IndentArgument bool
+	Body           []Tag
+	// Filters is the list of filters applied to the value of a [Variable]
+	// or [RawVariable] tag in order, as in {{price | currency}}.
+	// Filters are only parsed if [Options.Filters] is set.
+	Filters []string
+	// Value is the value of a [Pragma] tag, if any.
+	Value string
+	// Line is the line number of the start of a [Translation] tag,
+	// a [Section] tag named [TranslationSection],
+	// a [Pragma] tag, a [FrontMatter] tag, or a tag with filters.
+	// It is zero for other tags.
+	Line int
+}
+
+// TagType is the enumeration of kinds of [Tag].
+type TagType int
+
+// Tag types.
+const (
+	Literal TagType = iota
+	Variable
+	RawVariable
+	Section
+	InvertedSection
+	Partial
+	Block
+	Parent
+
+	// IndentPoint is a directive used to indicate where block indents should be inserted
+	// (i.e. at the beginning of logical lines).
+	IndentPoint
+	// Translation is a translatable message: a {{#_i18n}} section.
+	// S is the message, the section's text with variables written as
+	// {{name}} (escaped) or {{&name}} (raw) regardless of the template's delimiters.
+	// Translation tags have no body.
+	Translation
+	// Pragma is a compile option declared by the template,
+	// such as {{%STRICT}} or {{%ESCAPE=none}}.
+	// S is the pragma's name and Value is the text after "=", if any.
+	// Pragmas only appear at the top level of a template.
+	Pragma
+	// FrontMatter is the metadata block at the start of a template,
+	// between two lines of "---", if [Options] enables it.
+	// S is the block's content, which [ParseFrontMatter] decodes.
+	// It renders nothing.
+	FrontMatter
+)
+
+// TranslationSection is the name of the section that marks a translatable message.
+const TranslationSection = "_i18n"
+
+const (
+	defaultStartDelim = "{{"
+	defaultEndDelim   = "}}"
+)
+
+// Options is the set of optional syntax extensions for [ParseOptions].
+type Options struct {
+	// Filters is whether variable tags may apply filters to their value
+	// with a pipeline like {{price | currency}}.
+	// If it is false, "|" is an ordinary character in names.
+	Filters bool
+	// I18n is whether sections named [TranslationSection]
+	// are translatable messages, which are parsed as [Translation] tags.
+	// If it is false, they are ordinary sections.
+	I18n bool
+	// FrontMatter is whether a block between two lines of "---"
+	// at the start of the template is parsed as a [FrontMatter] tag.
+	// If it is false, the block is ordinary text.
+	FrontMatter bool
+}
+
+// Parse parses the Mustache template source into a tree of tags.
+func Parse(s string) ([]Tag, error) {
+	return ParseOptions(s, nil)
+}
+
+// ParseOptions is like [Parse], but enables the syntax extensions in opts.
+// A nil opts enables none.
+func ParseOptions(s string, opts *Options) ([]Tag, error) {
+	if opts == nil {
+		opts = new(Options)
+	}
+	type scope struct {
+		start      Tag
+		lineno     int
+		slice      *[]Tag
+		standalone bool
+	}
+
+	var result []Tag
+	startDelim := defaultStartDelim
+	endDelim := defaultEndDelim
+
+	stack := []scope{
+		{slice: &result},
+	}
+
+	lineno := 1
+	if content, rest, n, ok := cutFrontMatter(s); ok && opts.FrontMatter {
+		// The content starts on the line after the opening "---".
+		if _, err := parseFrontMatter(content, 2); err != nil {
+			return nil, err
+		}
+		result = append(result, Tag{Type: FrontMatter, S: content, Line: 2})
+		s = rest
+		lineno += n
+	}
+	newScope := func(newTag Tag) {
+		curr := stack[len(stack)-1].slice
+		*curr = append(*curr, newTag)
+		stack = append(stack, scope{
+			start:  newTag,
+			lineno: lineno,
+			slice:  &(*curr)[len(*curr)-1].Body,
+		})
+	}
+
+	appendLiteral := func(s string) {
+		if s != "" {
+			curr := stack[len(stack)-1].slice
+			*curr = append(*curr, Tag{
+				Type: Literal,
+				S:    s,
+			})
+		}
+	}
+
+	dedent := func(s string) string {
+		for _, curr := range stack {
+			if curr.start.Type != Block {
+				continue
+			}
+			var hasIndent bool
+			s, hasIndent = strings.CutPrefix(s, curr.start.Indent)
+			if !hasIndent {
+				break
+			}
+		}
+		return s
+	}
+
+	// Process (roughly) one line at a time.
+	for len(s) > 0 {
+		s = dedent(s)
+		eol := indexNextLine(s)
+
+		tagStart := strings.Index(s[:eol], startDelim)
+		if tagStart < 0 {
+			// Add indent point if there are no tags.
+			curr := stack[len(stack)-1].slice
+			*curr = append(*curr, Tag{Type: IndentPoint})
+		}
+
+		// Line has one or more tags.
+		// Hold off on adding literals until we know whether the line is standalone.
+		prevEnd := 0
+		for tagStart >= 0 {
+			special, key, tagEnd, err := cutTag(s, tagStart, startDelim, endDelim)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %v", lineno, err)
+			}
+			if n := strings.Count(s[tagStart:tagEnd], "\n"); n > 0 {
+				// Tag spanned multiple lines (comment).
+				// Update line position variables.
+				lineno += n
+				eol = tagEnd + indexNextLine(s[tagEnd:])
+			}
+			var filters []string
+			if opts.Filters && (special == 0 || special == '&') {
+				key, filters, err = cutFilters(key)
+				if err != nil {
+					return nil, fmt.Errorf("%d: %v", lineno, err)
+				}
+			}
+			if special != '!' && special != '=' {
+				// Non-comments must contain a non-whitespace character sequence.
+				if key == "" {
+					return nil, fmt.Errorf("%d: empty tag", lineno)
+				}
+				if i := strings.IndexFunc(key, unicode.IsSpace); i >= 0 {
+					return nil, fmt.Errorf("%d: extra words in %s tag", lineno, key[:i])
+				}
+			}
+
+			// "Standalone" tags are those that have nothing except whitespace
+			// before or after them on a line.
+			// Such tags are treated as though the leading whitespace the rest of the line were not present.
+			// "Standalone pair" tags are those where the whitespace after their closing tag
+			// is considered instead of the whitespace after the tag itself.
+			leadingText := s[prevEnd:tagStart]
+			restOfLine := s[tagEnd:eol]
+			trailingText := restOfLine
+			isParameter := special == '$' && stack[len(stack)-1].start.Type != Parent
+			isArgument := special == '$' && stack[len(stack)-1].start.Type == Parent
+			isStandalonePairTag := special == '<' || isParameter
+			if isStandalonePairTag {
+				i, err := elementEnd(key, s, tagEnd, startDelim, endDelim)
+				if err != nil {
+					return nil, fmt.Errorf("%d: %v", lineno, err)
+				}
+				trailingText = s[i : i+indexNextLine(s[i:])]
+			}
+			isStandalone := prevEnd == 0 &&
+				special != 0 && special != '&' &&
+				isSpace(leadingText) && isSpace(trailingText)
+			ignoreRestOfLine := isStandalone && !isStandalonePairTag
+
+			// Compute indentation.
+			var indent string
+			switch {
+			// Argument tags and standalone parameter tags that clear at the end
+			// use the following line's indentation.
+			case (isArgument || isParameter && isStandalone) && isSpace(restOfLine):
+				indent = lineIndentation(dedent(s[eol:]))
+				// Such tags also ignore the newline before their content.
+				ignoreRestOfLine = true
+			// Standalone tags use the indentation from their line.
+			case isStandalone:
+				indent = leadingText
+			}
+
+			// Add any literal text encountered since the last tag.
+			if !isStandalone {
+				// If this is the first tag we're processing on the line
+				// and it's not the argument block close tag at the start of the line,
+				// then add an indent point.
+				// The special argument block close tag is necessary
+				// because we want "{{$foo}}\n  foo\n{{/foo}}" to be treated as "foo\n".
+				// If we added an insert point, then we would add an extra indent after the newline
+				// during template execution.
+				if prevEnd == 0 && !(tagStart == 0 && special == '/' && stack[len(stack)-1].start.Type == Block && stack[len(stack)-2].start.Type == Parent) {
+					curr := stack[len(stack)-1].slice
+					*curr = append(*curr, Tag{Type: IndentPoint})
+				}
+				appendLiteral(leadingText)
+			}
+
+			switch special {
+			case '#':
+				// Section.
+				newScope(Tag{
+					Type: Section,
+					S:    key,
+				})
+			case '^':
+				// Inverted section.
+				newScope(Tag{
+					Type: InvertedSection,
+					S:    key,
+				})
+			case '!':
+				// Comment.
+			case '%':
+				// Pragma.
+				if len(stack) > 1 {
+					return nil, fmt.Errorf("%d: pragma %s inside %s", lineno, key, stack[len(stack)-1].start.S)
+				}
+				name, value, _ := strings.Cut(key, "=")
+				curr := stack[len(stack)-1].slice
+				*curr = append(*curr, Tag{Type: Pragma, S: strings.TrimSpace(name), Value: strings.TrimSpace(value), Line: lineno})
+			case '>':
+				// Partial.
+				curr := stack[len(stack)-1].slice
+				*curr = append(*curr, Tag{
+					Type:   Partial,
+					S:      key,
+					Indent: indent,
+				})
+			case '$':
+				// Block.
+				newScope(Tag{
+					Type:           Block,
+					S:              key,
+					Indent:         indent,
+					IndentArgument: isStandalone && isParameter && isSpace(restOfLine),
+				})
+				// If there's more content on the line,
+				// then add an indent point to act like the beginning of a line.
+				if isArgument && !ignoreRestOfLine {
+					curr := stack[len(stack)-1].slice
+					*curr = append(*curr, Tag{Type: IndentPoint})
+				}
+			case '<':
+				// Parent.
+				newScope(Tag{
+					Type:   Parent,
+					S:      key,
+					Indent: indent,
+				})
+				stack[len(stack)-1].standalone = isStandalone
+			case '/':
+				// Closing tag.
+				last := len(stack) - 1
+				if last == 0 {
+					return nil, fmt.Errorf("%d: %s/%s%s without opening", lineno, startDelim, key, endDelim)
+				}
+				if stack[last].start.Type == Parent {
+					// We already computed whether the closing tag clears when we opened the tag.
+					ignoreRestOfLine = stack[last].standalone
+				}
+				if want := stack[last].start.S; key != want {
+					return nil, fmt.Errorf("%d: mismatched %s/%s%s (last opened %s on line %d)",
+						lineno, startDelim, key, endDelim, want, stack[last].lineno)
+				}
+				if start := stack[last].start; start.Type == Section && start.S == TranslationSection {
+					// Record the line for TranslationTags.
+					parent := *stack[last-1].slice
+					parent[len(parent)-1].Line = stack[last].lineno
+				}
+				stack[last] = scope{}
+				stack = stack[:last]
+			case '=':
+				// Set delimiter tag.
+				var err error
+				startDelim, endDelim, err = splitSetDelimiterTag(key)
+				if err != nil {
+					return nil, fmt.Errorf("%d: %v", lineno, err)
+				}
+			case '&':
+				// Raw variable.
+				curr := stack[len(stack)-1].slice
+				*curr = append(*curr, variableTag(RawVariable, key, filters, lineno))
+			default:
+				// Escaped variable.
+				curr := stack[len(stack)-1].slice
+				*curr = append(*curr, variableTag(Variable, key, filters, lineno))
+			}
+
+			// Move to next tag in the line.
+			if ignoreRestOfLine {
+				prevEnd = eol
+				break
+			}
+			prevEnd = tagEnd
+			tagStart = nextIndex(s[:eol], tagEnd, startDelim)
+		}
+
+		// After we've processed all the tags in the line,
+		// add any remaining text as a literal
+		// and move on to the next line.
+		appendLiteral(s[prevEnd:eol])
+		s = s[eol:]
+		lineno++
+	}
+
+	// Return an error if there are open tags.
+	if i := len(stack) - 1; i > 0 {
+		last := stack[i]
+		return nil, fmt.Errorf("%d: unclosed %s", last.lineno, last.start.S)
+	}
+
+	if opts.I18n {
+		return TranslationTags(result)
+	}
+	return result, nil
+}
+
+// TranslationTags returns a copy of tags with each section named [TranslationSection]
+// replaced by a [Translation] tag, as [ParseOptions] does if [Options.I18n] is set.
+// It returns an error if such a section contains tags other than variables.
+func TranslationTags(tags []Tag) ([]Tag, error) {
+	result := make([]Tag, len(tags))
+	for i, t := range tags {
+		if t.Type == Section && t.S == TranslationSection {
+			msg, err := translationMessage(t.Body)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %v", t.Line, err)
+			}
+			t = Tag{Type: Translation, S: msg, Line: t.Line}
+		} else if t.Body != nil {
+			body, err := TranslationTags(t.Body)
+			if err != nil {
+				return nil, err
+			}
+			t.Body = body
+		}
+		result[i] = t
+	}
+	return result, nil
+}
+
+// variableTag returns a [Variable] or [RawVariable] tag
+// for the given name and filters.
+func variableTag(typ TagType, name string, filters []string, lineno int) Tag {
+	t := Tag{Type: typ, S: name}
+	if len(filters) > 0 {
+		t.Filters = filters
+		t.Line = lineno
+	}
+	return t
+}
+
+// cutFilters splits the key of a variable tag like "price | currency"
+// into the variable name and the names of its filters.
+func cutFilters(key string) (name string, filters []string, err error) {
+	name, rest, ok := strings.Cut(key, "|")
+	if !ok {
+		return key, nil, nil
+	}
+	for _, f := range strings.Split(rest, "|") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			return "", nil, errors.New("empty filter")
+		}
+		if strings.IndexFunc(f, unicode.IsSpace) >= 0 {
+			return "", nil, fmt.Errorf("extra words in filter %s", f)
+		}
+		filters = append(filters, f)
+	}
+	return strings.TrimSpace(name), filters, nil
+}
+
+// translationMessage returns the message for a [Translation] tag
+// with the given body.
+func translationMessage(body []Tag) (string, error) {
+	sb := new(strings.Builder)
+	for _, t := range body {
+		if len(t.Filters) > 0 {
+			return "", fmt.Errorf("filters in %s section", TranslationSection)
+		}
+		switch t.Type {
+		case Literal:
+			if strings.Contains(t.S, defaultStartDelim) {
+				return "", fmt.Errorf("%s in %s section text", defaultStartDelim, TranslationSection)
+			}
+			sb.WriteString(t.S)
+		case IndentPoint:
+		case Variable:
+			sb.WriteString(defaultStartDelim + t.S + defaultEndDelim)
+		case RawVariable:
+			sb.WriteString(defaultStartDelim + "&" + t.S + defaultEndDelim)
+		default:
+			return "", fmt.Errorf("%s section contains a tag other than a variable", TranslationSection)
+		}
+	}
+	return sb.String(), nil
+}
+
+// ParseMessage parses a [Translation] tag's message or its translation
+// into [Literal], [Variable], and [RawVariable] tags.
+// Text that does not form a variable tag is literal.
+func ParseMessage(message string) []Tag {
+	var tags []Tag
+	for {
+		start := strings.Index(message, defaultStartDelim)
+		if start < 0 {
+			break
+		}
+		end := strings.Index(message[start:], defaultEndDelim)
+		if end < 0 {
+			break
+		}
+		end += start
+		if start > 0 {
+			tags = append(tags, Tag{Type: Literal, S: message[:start]})
+		}
+		name, raw := strings.CutPrefix(message[start+len(defaultStartDelim):end], "&")
+		t := Tag{Type: Variable, S: strings.TrimSpace(name)}
+		if raw {
+			t.Type = RawVariable
+		}
+		tags = append(tags, t)
+		message = message[end+len(defaultEndDelim):]
+	}
+	if message != "" {
+		tags = append(tags, Tag{Type: Literal, S: message})
+	}
+	return tags
+}
+
+// cutTag parses the tag that starts at the index tagStart in s.
+// It is assumed that strings.HasPrefix(s[tagStart:], startDelim) reports true.
+func cutTag(s string, tagStart int, startDelim, endDelim string) (b byte, key string, tagEnd int, err error) {
+	// Find end of tag.
+	tagInnerStart := tagStart + len(startDelim)
+	isComment := strings.HasPrefix(s[tagInnerStart:], "!")
+	tagInnerEnd := tagInnerStart
+	tagEnd = -1
+	for ; tagInnerEnd+len(endDelim) <= len(s); tagInnerEnd++ {
+		if s[tagInnerEnd] == '\n' && !isComment {
+			// Newlines only permitted in comments.
+			return 0, "", -1, errors.New("unclosed tag")
+		}
+		if i := tagInnerEnd + len(endDelim); s[tagInnerEnd:i] == endDelim {
+			tagEnd = i
+			break
+		}
+	}
+	if tagEnd < 0 {
+		if isComment {
+			return 0, "", -1, errors.New("unclosed comment")
+		}
+		return 0, "", -1, errors.New("unclosed tag")
+	}
+
+	// Check for triple-bracketed (raw) variable.
+	// {{{foo}}} is treated identically to {{&foo}}.
+	isDefault := startDelim == "{{" && endDelim == "}}"
+	if isDefault && s[tagInnerStart] == '{' && strings.HasPrefix(s[tagEnd:], "}") {
+		tagInnerStart++
+		tagEnd++
+		return '&', strings.TrimSpace(s[tagInnerStart:tagInnerEnd]), tagEnd, nil
+	}
+
+	// Extract first character if it's one of the known specials.
+	inner := s[tagInnerStart:tagInnerEnd]
+	if inner, isDelimiter := strings.CutPrefix(inner, "="); isDelimiter {
+		inner, hasFinalEquals := strings.CutSuffix(inner, "=")
+		if !hasFinalEquals {
+			return '=', inner, tagEnd, fmt.Errorf("%s does not end with =%s", s[tagStart:tagEnd], endDelim)
+		}
+		return '=', strings.TrimSpace(inner), tagEnd, nil
+	}
+	if len(inner) > 1 && strings.IndexByte(`#^!<>$/&%`, inner[0]) >= 0 {
+		b = inner[0]
+		inner = inner[1:]
+	}
+	return b, strings.TrimSpace(inner), tagEnd, nil
+}
+
+// splitSetDelimiterTag splits the inner content of a set delimiter tag
+// into the start and end delimiters.
+func splitSetDelimiterTag(s string) (startDelim, endDelim string, err error) {
+	i := strings.IndexFunc(s, unicode.IsSpace)
+	if i == 0 {
+		return "", "", errors.New("set delimiter tag empty")
+	}
+	if i < 0 {
+		return "", "", errors.New("set delimiter tag missing an end delimiter")
+	}
+	j := nextIndexFunc(s, i, isNonSpace)
+	if j < 0 {
+		return "", "", errors.New("set delimiter tag missing an end delimiter")
+	}
+	if k := nextIndexFunc(s, j, unicode.IsSpace); k >= 0 {
+		return "", "", errors.New("set delimiter tag has more than two delimiters")
+	}
+	return s[:i], s[j:], nil
+}
+
+// elementEnd returns the end of the matching end tag.
+// The search starts at tagEnd,
+// the index in s of the end of the start tag with the given name.
+func elementEnd(name string, s string, tagEnd int, startDelim, endDelim string) (int, error) {
+	level := 1
+	i := tagEnd
+	for level > 0 {
+		tagStart := nextIndex(s, i, startDelim)
+		if tagStart < 0 {
+			return -1, fmt.Errorf("unclosed %s", name)
+		}
+		special, key, tagEnd, err := cutTag(s, tagStart, startDelim, endDelim)
+		if err != nil {
+			return -1, fmt.Errorf("unclosed %s", name)
+		}
+		switch special {
+		case '#', '^', '$', '<':
+			if key == name {
+				level++
+			}
+		case '/':
+			if key == name {
+				level--
+			}
+		case '=':
+			var err error
+			startDelim, endDelim, err = splitSetDelimiterTag(key)
+			if err != nil {
+				return 0, err
+			}
+		}
+		i = tagEnd
+	}
+	return i, nil
+}
+
+// WalkTags returns an iterator that visits all tags in the given slice in pre-order.
+func WalkTags(tags []Tag) iter.Seq[Tag] {
+	var walk func(tags []Tag, yield func(Tag) bool) bool
+	walk = func(tags []Tag, yield func(Tag) bool) bool {
+		for _, t := range tags {
+			if !yield(t) {
+				return false
+			}
+			if !walk(t.Body, yield) {
+				return false
+			}
+		}
+		return true
+	}
+	return func(yield func(Tag) bool) {
+		walk(tags, yield)
+	}
+}
+
+// CondenseLiteralsWithoutIndentation joins the strings of the leading [Literal] tags in the slice
+// into a single literal tag and returns how many were used.
+// Any [IndentPoint] tags are ignored.
+func CondenseLiteralsWithoutIndentation(tags []Tag) (_ Tag, n int) {
+	sb := new(strings.Builder)
+	for i, t := range tags {
+		switch t.Type {
+		case Literal:
+			sb.WriteString(t.S)
+		case IndentPoint:
+			// Skip.
+		default:
+			return Tag{
+				Type: Literal,
+				S:    sb.String(),
+			}, i
+		}
+	}
+	return Tag{
+		Type: Literal,
+		S:    sb.String(),
+	}, len(tags)
+}
+
+// TagsEqual reports whether t1 and t2 are the same tags with equal bodies.
+func TagsEqual(t1, t2 Tag) bool {
+	if t1.Type != t2.Type || t1.S != t2.S || t1.Indent != t2.Indent || t1.Value != t2.Value || !slices.Equal(t1.Filters, t2.Filters) {
+		return false
+	}
+	return slices.EqualFunc(t1.Body, t2.Body, TagsEqual)
+}
+
+// nextIndex is like [strings.Index],
+// but takes in a starting index.
+// nextIndex will not return a value less than start
+// unless substr is not found within s[start:],
+// in which case nextIndex will return -1.
+func nextIndex(s string, start int, substr string) int {
+	i := strings.Index(s[start:], substr)
+	if i < 0 {
+		return i
+	}
+	return start + i
+}
+
+// nextIndexFunc is like [strings.IndexFunc],
+// but takes in a starting index.
+// nextIndexFunc will not return a value less than start
+// unless substr is not found within s[start:],
+// in which case nextIndexFunc will return -1.
+func nextIndexFunc(s string, start int, f func(rune) bool) int {
+	i := strings.IndexFunc(s[start:], f)
+	if i < 0 {
+		return i
+	}
+	return start + i
+}
+
+// indexNextLine returns the index of the last byte of the first line of s (exclusive).
+func indexNextLine(s string) int {
+	i := strings.IndexByte(s, '\n')
+	if i < 0 {
+		return len(s)
+	}
+	return i + 1
+}
+
+// lineIndentation returns the longest whitespace-only prefix of line.
+func lineIndentation(line string) string {
+	firstNonSpace := strings.IndexFunc(line, func(c rune) bool {
+		return !unicode.Is(unicode.Zs, c) && c != '\t'
+	})
+	if firstNonSpace < 0 {
+		return line
+	}
+	return line[:firstNonSpace]
+}
+
+// isSpace reports whether all the characters in s are whitespace characters.
+func isSpace(s string) bool {
+	return !strings.ContainsFunc(s, isNonSpace)
+}
+
+func isNonSpace(c rune) bool {
+	return !unicode.IsSpace(c)
+}
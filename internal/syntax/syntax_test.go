@@ -0,0 +1,264 @@
+// Copyright (c) 2025 Kagi Search
+// SPDX-License-Identifier: MIT
+
+package syntax
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseTranslation(t *testing.T) {
+	tests := []struct {
+		source string
+		want   Tag
+	}{
+		{"{{#_i18n}}Hello{{/_i18n}}", Tag{Type: Translation, S: "Hello", Line: 1}},
+		{"\n{{#_i18n}}Hello, {{ name }}{{&html}}{{{raw}}}!{{/_i18n}}", Tag{Type: Translation, S: "Hello, {{name}}{{&html}}{{&raw}}!", Line: 2}},
+		{"{{=<% %>=}}<%#_i18n%><%a.b%> and <%! comment %>more<%/_i18n%>", Tag{Type: Translation, S: "{{a.b}} and more", Line: 1}},
+		{"{{#_i18n}}\n  One\n  Two\n{{/_i18n}}", Tag{Type: Translation, S: "  One\n  Two\n", Line: 1}},
+	}
+	opts := &Options{I18n: true}
+	for _, test := range tests {
+		tags, err := ParseOptions(test.source, opts)
+		if err != nil {
+			t.Errorf("ParseOptions(%q): %v", test.source, err)
+			continue
+		}
+		var got []Tag
+		for tag := range WalkTags(tags) {
+			if tag.Type == Translation {
+				got = append(got, tag)
+			}
+		}
+		if len(got) != 1 || !TagsEqual(got[0], test.want) || got[0].Line != test.want.Line {
+			t.Errorf("ParseOptions(%q) translations = %+v; want [%+v]", test.source, got, test.want)
+		}
+	}
+
+	badSources := []string{
+		"{{#_i18n}}{{#a}}b{{/a}}{{/_i18n}}",
+		"{{#_i18n}}{{>partial}}{{/_i18n}}",
+		"{{=<% %>=}}<%#_i18n%>{{literal}}<%/_i18n%>",
+		"{{#a}}\n{{#_i18n}}{{#b}}c{{/b}}{{/_i18n}}{{/a}}",
+	}
+	for _, source := range badSources {
+		if _, err := ParseOptions(source, opts); err == nil {
+			t.Errorf("ParseOptions(%q) did not return an error", source)
+		}
+		// Without the option, the sections are ordinary sections.
+		tags, err := Parse(source)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", source, err)
+			continue
+		}
+		for tag := range WalkTags(tags) {
+			if tag.Type == Translation {
+				t.Errorf("Parse(%q) = %+v; want no translations", source, tags)
+			}
+		}
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	tests := []struct {
+		source string
+		want   Tag
+	}{
+		{"{{price}}", Tag{Type: Variable, S: "price"}},
+		{"{{price | currency}}", Tag{Type: Variable, S: "price", Filters: []string{"currency"}, Line: 1}},
+		{"\n{{&a.b|upper|trim}}", Tag{Type: RawVariable, S: "a.b", Filters: []string{"upper", "trim"}, Line: 2}},
+		{"{{{ html | sanitize }}}", Tag{Type: RawVariable, S: "html", Filters: []string{"sanitize"}, Line: 1}},
+		{"{{=<% %>=}}<% . | json %>", Tag{Type: Variable, S: ".", Filters: []string{"json"}, Line: 1}},
+	}
+	for _, test := range tests {
+		tags, err := ParseOptions(test.source, &Options{Filters: true})
+		if err != nil {
+			t.Errorf("ParseOptions(%q): %v", test.source, err)
+			continue
+		}
+		var got []Tag
+		for tag := range WalkTags(tags) {
+			if tag.Type == Variable || tag.Type == RawVariable {
+				got = append(got, tag)
+			}
+		}
+		if len(got) != 1 || !TagsEqual(got[0], test.want) || got[0].Line != test.want.Line {
+			t.Errorf("ParseOptions(%q) variables = %+v; want [%+v]", test.source, got, test.want)
+		}
+	}
+
+	badSources := []string{
+		"{{price |}}",
+		"{{price || currency}}",
+		"{{price | to currency}}",
+		"{{ | currency}}",
+	}
+	for _, source := range badSources {
+		if _, err := ParseOptions(source, &Options{Filters: true}); err == nil {
+			t.Errorf("ParseOptions(%q) did not return an error", source)
+		}
+	}
+	if _, err := ParseOptions("{{#_i18n}}{{price | currency}}{{/_i18n}}", &Options{Filters: true, I18n: true}); err == nil {
+		t.Error("ParseOptions did not return an error for filters in a translation section")
+	}
+
+	// Without the option, "|" is part of the name.
+	tags, err := Parse("{{a|b}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Tag{Type: Variable, S: "a|b"}); len(tags) != 2 || !TagsEqual(tags[1], want) {
+		t.Errorf("Parse(\"{{a|b}}\") = %+v; want [IndentPoint %+v]", tags, want)
+	}
+}
+
+func TestParsePragma(t *testing.T) {
+	tags, err := Parse("{{% STRICT }}\n{{%ESCAPE=none}}\nHello\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Tag{
+		{Type: Pragma, S: "STRICT", Line: 1},
+		{Type: Pragma, S: "ESCAPE", Value: "none", Line: 2},
+		{Type: IndentPoint},
+		{Type: Literal, S: "Hello\n"},
+	}
+	if !slices.EqualFunc(tags, want, TagsEqual) {
+		t.Errorf("Parse(...) = %+v; want %+v", tags, want)
+	}
+	for i := range 2 {
+		if tags[i].Line != want[i].Line {
+			t.Errorf("tags[%d].Line = %d; want %d", i, tags[i].Line, want[i].Line)
+		}
+	}
+
+	if _, err := Parse("{{#a}}{{%STRICT}}{{/a}}"); err == nil {
+		t.Error("Parse did not return an error for a pragma inside a section")
+	}
+}
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		want    []Tag
+	}{
+		{"", nil},
+		{"Hello", []Tag{{Type: Literal, S: "Hello"}}},
+		{
+			"Hello, {{ name }}{{&html}}!",
+			[]Tag{
+				{Type: Literal, S: "Hello, "},
+				{Type: Variable, S: "name"},
+				{Type: RawVariable, S: "html"},
+				{Type: Literal, S: "!"},
+			},
+		},
+		{"{{a} {{", []Tag{{Type: Literal, S: "{{a} {{"}}},
+	}
+	for _, test := range tests {
+		got := ParseMessage(test.message)
+		if !slices.EqualFunc(got, test.want, TagsEqual) {
+			t.Errorf("ParseMessage(%q) = %+v; want %+v", test.message, got, test.want)
+		}
+	}
+}
+
+func TestParseFrontMatter(t *testing.T) {
+	tests := []struct {
+		source string
+		want   []MetaField
+	}{
+		{
+			"---\ntitle: Home page\ncache_ttl: 300\n\n# comment\ndraft: false\nsubtitle: \"a: b\"\nratio: 1.5\n---\nHello\n",
+			[]MetaField{
+				{"title", "Home page"},
+				{"cache_ttl", json.Number("300")},
+				{"draft", false},
+				{"subtitle", "a: b"},
+				{"ratio", json.Number("1.5")},
+			},
+		},
+		{
+			"---\n{\"title\": \"Home\", \"cache-ttl\": 300, \"draft\": true}\n---\nHello\n",
+			[]MetaField{
+				{"title", "Home"},
+				{"cache-ttl", json.Number("300")},
+				{"draft", true},
+			},
+		},
+		{"---\n---\nHello\n", nil},
+	}
+	opts := &Options{FrontMatter: true}
+	for _, test := range tests {
+		tags, err := ParseOptions(test.source, opts)
+		if err != nil {
+			t.Errorf("ParseOptions(%q): %v", test.source, err)
+			continue
+		}
+		if len(tags) != 3 || tags[0].Type != FrontMatter || tags[0].Line != 2 || !TagsEqual(tags[2], Tag{Type: Literal, S: "Hello\n"}) {
+			t.Errorf("ParseOptions(%q) = %+v; want [FrontMatter IndentPoint Literal]", test.source, tags)
+			continue
+		}
+		got, err := ParseFrontMatter(tags[0].S)
+		if err != nil {
+			t.Errorf("ParseFrontMatter(%q): %v", tags[0].S, err)
+			continue
+		}
+		if !slices.Equal(got, test.want) {
+			t.Errorf("ParseFrontMatter(%q) = %v; want %v", tags[0].S, got, test.want)
+		}
+	}
+
+	// Without a closing line, "---" is text.
+	tags, err := ParseOptions("---\nHello\n", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) == 0 || tags[0].Type == FrontMatter {
+		t.Errorf("ParseOptions(\"---\\nHello\\n\") = %+v; want no front matter", tags)
+	}
+	// Unless front matter is enabled, the block is text.
+	for _, source := range []string{"---\ntitle: Home\n---\nHello\n", "---\n- a\n---\n- b\n"} {
+		tags, err := Parse(source)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", source, err)
+			continue
+		}
+		var text strings.Builder
+		for _, tag := range tags {
+			if tag.Type == FrontMatter {
+				t.Errorf("Parse(%q) = %+v; want no front matter", source, tags)
+			}
+			text.WriteString(tag.S)
+		}
+		if text.String() != source {
+			t.Errorf("Parse(%q) has text %q; want the source", source, text.String())
+		}
+	}
+	// Tags after the front matter are numbered by their line in the template.
+	tags, err = ParseOptions("---\na: 1\n---\n{{#_i18n}}Hi{{/_i18n}}", &Options{I18n: true, FrontMatter: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := tags[len(tags)-1]; got.Type != Translation || got.Line != 4 {
+		t.Errorf("translation tag = %+v; want line 4", got)
+	}
+
+	badSources := map[string]string{
+		"---\ntitle\n---\n":                      "2: ",
+		"---\ntitle: a\ntitle: b\n---\n":         "3: ",
+		"---\n1st: a\n---\n":                     "2: ",
+		"---\n{\"a\": [1]}\n---\n":               "2: ",
+		"---\n{\n\"a\": 1,\n\"b\": {}\n}\n---\n": "4: ",
+		"---\n{\"a\": 1} x\n---\n":               "2: ",
+	}
+	for source, prefix := range badSources {
+		_, err := ParseOptions(source, opts)
+		if err == nil || !strings.HasPrefix(err.Error(), prefix) {
+			t.Errorf("ParseOptions(%q) error = %v; want prefix %q", source, err, prefix)
+		}
+	}
+}